@@ -0,0 +1,125 @@
+package nullable
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// The functions of this file implement the SQL aggregate functions over nullable values
+// loaded in memory. As in SQL, nulls are ignored, and the aggregate of no value
+// (an empty input or only nulls) is null, except for Count which is 0.
+// Each function has a variant over an iter.Seq with the Seq suffix.
+
+// Count returns the number of non-null values, as the SQL COUNT(x).
+func Count[T any](values []Of[T]) int {
+	return CountSeq(slices.Values(values))
+}
+
+// CountSeq is Count over a sequence.
+func CountSeq[T any](seq iter.Seq[Of[T]]) int {
+	count := 0
+
+	for n := range seq {
+		if !n.IsNull() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Sum returns the sum of the non-null values, as the SQL SUM(x).
+func Sum[T Number](values []Of[T]) Of[T] {
+	return SumSeq(slices.Values(values))
+}
+
+// SumSeq is Sum over a sequence.
+func SumSeq[T Number](seq iter.Seq[Of[T]]) Of[T] {
+	return reduce(seq, func(sum, v T) T { return sum + v })
+}
+
+// Min returns the least non-null value, as the SQL MIN(x).
+func Min[T cmp.Ordered](values []Of[T]) Of[T] {
+	return MinSeq(slices.Values(values))
+}
+
+// MinSeq is Min over a sequence.
+func MinSeq[T cmp.Ordered](seq iter.Seq[Of[T]]) Of[T] {
+	return reduce(seq, func(least, v T) T { return min(least, v) })
+}
+
+// Max returns the greatest non-null value, as the SQL MAX(x).
+func Max[T cmp.Ordered](values []Of[T]) Of[T] {
+	return MaxSeq(slices.Values(values))
+}
+
+// MaxSeq is Max over a sequence.
+func MaxSeq[T cmp.Ordered](seq iter.Seq[Of[T]]) Of[T] {
+	return reduce(seq, func(greatest, v T) T { return max(greatest, v) })
+}
+
+// Avg returns the mean of the non-null values, as the SQL AVG(x).
+func Avg[T Number](values []Of[T]) Of[float64] {
+	return AvgSeq(slices.Values(values))
+}
+
+// AvgSeq is Avg over a sequence.
+func AvgSeq[T Number](seq iter.Seq[Of[T]]) Of[float64] {
+	sum, count := 0.0, 0
+
+	for n := range seq {
+		if !n.IsNull() {
+			sum += float64(*n.val)
+			count++
+		}
+	}
+
+	if count == 0 {
+		return Null[float64]()
+	}
+
+	return FromValue(sum / float64(count))
+}
+
+// Compact returns the non-null values, in order.
+func Compact[T any](values []Of[T]) []T {
+	return CompactSeq(slices.Values(values))
+}
+
+// CompactSeq is Compact over a sequence.
+func CompactSeq[T any](seq iter.Seq[Of[T]]) []T {
+	out := []T{}
+
+	for n := range seq {
+		if !n.IsNull() {
+			out = append(out, *n.val)
+		}
+	}
+
+	return out
+}
+
+// reduce combines the non-null values of seq with f, or returns null if there is none.
+func reduce[T any](seq iter.Seq[Of[T]], f func(T, T) T) Of[T] {
+	var (
+		acc   T
+		found bool
+	)
+
+	for n := range seq {
+		switch {
+		case n.IsNull():
+		case found:
+			acc = f(acc, *n.val)
+		default:
+			acc, found = *n.val, true
+		}
+	}
+
+	if !found {
+		return Null[T]()
+	}
+
+	return FromValue(acc)
+}
@@ -0,0 +1,138 @@
+package nullable
+
+import (
+	"cmp"
+	"errors"
+)
+
+// The functions of this file implement the SQL arithmetic and comparison operators on Of[T]:
+// the result is null as soon as an operand is null, so that values computed in Go
+// are the ones the equivalent SQL expression would give.
+// As in Go, integer overflows wrap around where PostgreSQL raises an error.
+
+// Number is the constraint of the types supported by the arithmetic functions.
+type Number interface {
+	~int | ~int16 | ~int32 | ~int64 | ~float64
+}
+
+// Integer is the constraint of the types supported by Mod.
+type Integer interface {
+	~int | ~int16 | ~int32 | ~int64
+}
+
+// ErrDivisionByZero is returned by Div and Mod when the divisor is zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Add returns the SQL a + b.
+func Add[T Number](a, b Of[T]) Of[T] {
+	return apply(a, b, func(x, y T) T { return x + y })
+}
+
+// Sub returns the SQL a - b.
+func Sub[T Number](a, b Of[T]) Of[T] {
+	return apply(a, b, func(x, y T) T { return x - y })
+}
+
+// Mul returns the SQL a * b.
+func Mul[T Number](a, b Of[T]) Of[T] {
+	return apply(a, b, func(x, y T) T { return x * y })
+}
+
+// Div returns the SQL a / b. Integer divisions truncate toward zero, as in SQL.
+// A zero divisor returns ErrDivisionByZero, as PostgreSQL does, unless a is null.
+func Div[T Number](a, b Of[T]) (Of[T], error) {
+	return divide(a, b, func(x, y T) T { return x / y })
+}
+
+// DivOrNull returns the SQL a / b, or null if b is zero, as SQLite and MySQL do.
+func DivOrNull[T Number](a, b Of[T]) Of[T] {
+	div, _ := Div(a, b)
+
+	return div
+}
+
+// Mod returns the SQL a % b, whose sign is the one of a, as in SQL.
+// A zero divisor returns ErrDivisionByZero, as PostgreSQL does, unless a is null.
+func Mod[T Integer](a, b Of[T]) (Of[T], error) {
+	return divide(a, b, func(x, y T) T { return x % y })
+}
+
+// ModOrNull returns the SQL a % b, or null if b is zero, as SQLite and MySQL do.
+func ModOrNull[T Integer](a, b Of[T]) Of[T] {
+	mod, _ := Mod(a, b)
+
+	return mod
+}
+
+// Neg returns the SQL -a.
+func Neg[T Number](a Of[T]) Of[T] {
+	if a.IsNull() {
+		return Null[T]()
+	}
+
+	return FromValue(-*a.val)
+}
+
+// Abs returns the SQL abs(a).
+func Abs[T Number](a Of[T]) Of[T] {
+	switch {
+	case a.IsNull():
+		return Null[T]()
+	case *a.val >= 0:
+		return FromValue(*a.val)
+	}
+
+	return FromValue(-*a.val)
+}
+
+// Eq returns the SQL a = b.
+func Eq[T cmp.Ordered](a, b Of[T]) Of[bool] {
+	return apply(a, b, func(x, y T) bool { return x == y })
+}
+
+// Ne returns the SQL a <> b.
+func Ne[T cmp.Ordered](a, b Of[T]) Of[bool] {
+	return apply(a, b, func(x, y T) bool { return x != y })
+}
+
+// Lt returns the SQL a < b.
+func Lt[T cmp.Ordered](a, b Of[T]) Of[bool] {
+	return apply(a, b, func(x, y T) bool { return x < y })
+}
+
+// Le returns the SQL a <= b.
+func Le[T cmp.Ordered](a, b Of[T]) Of[bool] {
+	return apply(a, b, func(x, y T) bool { return x <= y })
+}
+
+// Gt returns the SQL a > b.
+func Gt[T cmp.Ordered](a, b Of[T]) Of[bool] {
+	return apply(a, b, func(x, y T) bool { return x > y })
+}
+
+// Ge returns the SQL a >= b.
+func Ge[T cmp.Ordered](a, b Of[T]) Of[bool] {
+	return apply(a, b, func(x, y T) bool { return x >= y })
+}
+
+// divide returns f(a, b), or null if a or b is null, or ErrDivisionByZero if b is zero.
+func divide[T Number](a, b Of[T], f func(T, T) T) (Of[T], error) {
+	if a.IsNull() || b.IsNull() {
+		return Null[T](), nil
+	}
+
+	if *b.val == 0 {
+		return Null[T](), ErrDivisionByZero
+	}
+
+	return FromValue(f(*a.val, *b.val)), nil
+}
+
+// apply returns f(a, b), or null if a or b is null.
+func apply[T, R any](a, b Of[T], f func(T, T) R) Of[R] {
+	if a.IsNull() || b.IsNull() {
+		return Null[R]()
+	}
+
+	return FromValue(f(*a.val, *b.val))
+}
@@ -0,0 +1,73 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// codec holds the registered database conversions of a type, see RegisterCodec.
+type codec[T any] struct {
+	scan  func(any) (T, error)
+	value func(T) (driver.Value, error)
+}
+
+// codecs is the registry of codecs by type.
+var codecs sync.Map // map[reflect.Type]codec[T]
+
+// RegisterCodec registers the database conversions of T used by Of[T].Scan and Of[T].Value
+// before any built-in handling. It permits to support types which can't implement
+// sql.Scanner or driver.Valuer, such as third-party types:
+//
+//	nullable.RegisterCodec(
+//		func(v any) (civil.Date, error) {
+//			t, ok := v.(time.Time)
+//			if !ok {
+//				return civil.Date{}, fmt.Errorf("cannot scan %T as civil.Date", v)
+//			}
+//
+//			return civil.DateOf(t), nil
+//		},
+//		func(d civil.Date) (driver.Value, error) { return d.String(), nil },
+//	)
+//
+// NULL values are handled by Of[T], so scan never receives nil and value never receives a null value.
+// A nil scan or value function keeps the built-in handling for that direction.
+// RegisterCodec should be called at program initialization, registering a codec of T again replaces it.
+// Lookups are safe for concurrent use.
+func RegisterCodec[T any](scan func(any) (T, error), value func(T) (driver.Value, error)) {
+	codecs.Store(reflect.TypeFor[T](), codec[T]{scan: scan, value: value})
+}
+
+// lookupCodec returns the codec registered for T, if any.
+func lookupCodec[T any]() (codec[T], bool) {
+	c, ok := codecs.Load(reflect.TypeFor[T]())
+	if !ok {
+		return codec[T]{}, false
+	}
+
+	return c.(codec[T]), true
+}
+
+func (n *Of[T]) scanCodec(scan func(any) (T, error), v any) error {
+	if n == nil {
+		return errors.New("calling scanCodec on nil receiver")
+	}
+
+	if v == nil {
+		n.SetNull()
+
+		return nil
+	}
+
+	value, err := scan(v)
+	if err != nil {
+		return fmt.Errorf("registered codec scanning error on nullable : %w", err)
+	}
+
+	n.SetValue(value)
+
+	return nil
+}
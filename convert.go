@@ -0,0 +1,44 @@
+package nullable
+
+import (
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the text timestamp layouts accepted when scanning.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	time.DateOnly,
+}
+
+// parseTimestamp parses s with the first matching layout of timestampLayouts.
+// The monotonic clock reading printed by time.Time.String, as stored by some drivers
+// (e.g. modernc.org/sqlite in text columns), is ignored.
+func parseTimestamp(s string) (time.Time, bool) {
+	s, _, _ = strings.Cut(s, " m=")
+
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// asString returns the string held by a string or a []byte driver value.
+func asString(v any) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+
+	s, _ := v.(string)
+
+	return s
+}
@@ -0,0 +1,283 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date without time of day nor location, matching the PostgreSQL date type.
+// It is encoded as YYYY-MM-DD in JSON and in database.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// TimeOfDay is a wall clock time without date nor location, matching the PostgreSQL time type.
+// It is encoded as HH:MM:SS[.ffffff] in JSON and in database.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// DateOf returns the Date of t in its location.
+// Use DateOf(t.In(loc)) to get the date in another location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// In returns the time.Time at midnight of d in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsValid returns true iff d is an existing calendar date.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// String returns the date formatted as YYYY-MM-DD, with a " BC" suffix for years before 1 AD.
+func (d Date) String() string {
+	if d.Year <= 0 {
+		return fmt.Sprintf("%04d-%02d-%02d BC", 1-d.Year, int(d.Month), d.Day)
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.IsValid() {
+		return nil, fmt.Errorf("invalid date %d-%d-%d", d.Year, int(d.Month), d.Day)
+	}
+
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Date) UnmarshalText(data []byte) error {
+	date, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+
+	*d = date
+
+	return nil
+}
+
+// ParseDate parses a YYYY-MM-DD date, optionally followed by " BC" as PostgreSQL outputs it.
+func ParseDate(s string) (Date, error) {
+	value, bc := strings.CutSuffix(s, " BC")
+	parts := strings.Split(value, "-")
+
+	if len(parts) != 3 || len(parts[0]) < 4 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return Date{}, &ParseError{Type: "nullable.Date", Input: s, Err: errors.New("expecting YYYY-MM-DD")}
+	}
+
+	var numbers [3]int
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || strings.Trim(part, "0123456789") != "" {
+			return Date{}, &ParseError{Type: "nullable.Date", Input: s, Err: errors.New("expecting YYYY-MM-DD")}
+		}
+
+		numbers[i] = number
+	}
+
+	d := Date{Year: numbers[0], Month: time.Month(numbers[1]), Day: numbers[2]}
+	if bc {
+		d.Year = 1 - d.Year
+	}
+
+	if !d.IsValid() {
+		return Date{}, &ParseError{Type: "nullable.Date", Input: s, Err: errors.New("date out of range")}
+	}
+
+	return d, nil
+}
+
+// TimeOfDayOf returns the TimeOfDay of t in its location.
+// Use TimeOfDayOf(t.In(loc)) to get the time of day in another location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// On returns the time.Time at t on the given date in the given location.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// IsValid returns true iff t is a valid time of day.
+// As in PostgreSQL, 24:00:00 is valid.
+func (t TimeOfDay) IsValid() bool {
+	if t.Hour == 24 {
+		return t.Minute == 0 && t.Second == 0 && t.Nanosecond == 0
+	}
+
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// String returns the time formatted as HH:MM:SS[.fffffffff] without trailing zeros.
+func (t TimeOfDay) String() string {
+	out := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		out += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+
+	return out
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("invalid time of day %d:%d:%d.%d", t.Hour, t.Minute, t.Second, t.Nanosecond)
+	}
+
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	tod, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+
+	*t = tod
+
+	return nil
+}
+
+// ParseTimeOfDay parses a HH:MM[:SS[.fffffffff]] time of day.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	invalid := &ParseError{Type: "nullable.TimeOfDay", Input: s, Err: errors.New("expecting HH:MM:SS[.ffffff]")}
+
+	clock, frac, hasFrac := strings.Cut(s, ".")
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 || (hasFrac && len(parts) != 3) {
+		return TimeOfDay{}, invalid
+	}
+
+	var numbers [3]int
+
+	for i, part := range parts {
+		if len(part) != 2 || strings.Trim(part, "0123456789") != "" {
+			return TimeOfDay{}, invalid
+		}
+
+		numbers[i], _ = strconv.Atoi(part)
+	}
+
+	t := TimeOfDay{Hour: numbers[0], Minute: numbers[1], Second: numbers[2]}
+
+	if hasFrac {
+		if frac == "" || len(frac) > 9 || strings.Trim(frac, "0123456789") != "" {
+			return TimeOfDay{}, invalid
+		}
+
+		t.Nanosecond, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+
+	if !t.IsValid() {
+		return TimeOfDay{}, &ParseError{Type: "nullable.TimeOfDay", Input: s, Err: errors.New("time of day out of range")}
+	}
+
+	return t, nil
+}
+
+func (n *Of[T]) scanDate(v any) error {
+	if n == nil {
+		return errors.New("calling scanDate on nil receiver")
+	}
+
+	switch value := v.(type) {
+	case nil:
+		n.SetNull()
+	case time.Time:
+		n.SetValue(any(DateOf(value)).(T))
+	case string, []byte:
+		s := asString(value)
+
+		d, err := ParseDate(s)
+		if err != nil {
+			t, ok := parseTimestamp(s)
+			if !ok {
+				return err
+			}
+
+			d = DateOf(t)
+		}
+
+		n.SetValue(any(d).(T))
+	default:
+		return fmt.Errorf("nullable database scanning date : cannot scan type %T", v)
+	}
+
+	return nil
+}
+
+func (n *Of[T]) scanTimeOfDay(v any) error {
+	if n == nil {
+		return errors.New("calling scanTimeOfDay on nil receiver")
+	}
+
+	switch value := v.(type) {
+	case nil:
+		n.SetNull()
+	case time.Time:
+		n.SetValue(any(TimeOfDayOf(value)).(T))
+	case string, []byte:
+		s := asString(value)
+
+		tod, err := ParseTimeOfDay(s)
+		if err != nil {
+			t, ok := parseTimestamp(s)
+			if !ok {
+				return err
+			}
+
+			tod = TimeOfDayOf(t)
+		}
+
+		n.SetValue(any(tod).(T))
+	default:
+		return fmt.Errorf("nullable database scanning time : cannot scan type %T", v)
+	}
+
+	return nil
+}
+
+// calendarValue returns the text of a Date or TimeOfDay for the driver.Valuer interface.
+func calendarValue(v any) (driver.Value, error) {
+	switch value := v.(type) {
+	case *Date:
+		b, err := value.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("nullable database value error : %w", err)
+		}
+
+		return string(b), nil
+	case *TimeOfDay:
+		b, err := value.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("nullable database value error : %w", err)
+		}
+
+		return string(b), nil
+	}
+
+	return nil, fmt.Errorf("type %T is not a calendar type", v)
+}
@@ -3,5 +3,35 @@ package nullable provides Go nullable data type.
 This package handles database values as sql.Nullxxx does but with generic features.
 This package make possible to scan and store any structs' type to json and jsonb Postgresql data type
 thanks to [github.com/jmoiron/sqlx] for example.
+
+# Driver values
+
+Database drivers don't agree on the Go types of the values they return:
+pgx returns native types, while the MySQL text protocol and some SQLite drivers
+return []byte or string for numbers, booleans and timestamps.
+Of[T].Scan then accepts the following driver values for each target type T.
+A nil driver value always scans as null, other sources return an error.
+
+	T \ source           int64           float64         bool         []byte / string                    time.Time
+	string               decimal text    shortest text   true/false   as is                              RFC 3339 text
+	int, int16/32/64     in range        integral value  -            base 10 integer                    -
+	float64              converted       as is           -            decimal number                     -
+	bool                 1 or 0          -               as is        1 t T true TRUE True 0 f F ...     -
+	uuid.UUID            -               -               -            text form, or 16 raw bytes         -
+	time.Time            -               -               -            timestamp text, see below          as is
+	time.Duration        nanoseconds     -               -            interval text, see ParseInterval   -
+	Date                 -               -               -            YYYY-MM-DD or timestamp text       date part
+	TimeOfDay            -               -               -            HH:MM:SS or timestamp text         clock part
+	netip.Addr/Prefix    -               -               -            inet/cidr text                     -
+	net.HardwareAddr     -               -               -            macaddr text                       -
+	Enum                 -               -               -            one of the enum values             -
+	JSON                 JSON number     JSON number     JSON bool    JSON text                          -
+
+Named types, such as type UserID int64, are scanned as their underlying type.
+Timestamp text is RFC 3339, or the SQL "YYYY-MM-DD HH:MM:SS[.fffffffff][±hh[:mm]]" form,
+or a date only; a timestamp without offset is considered UTC.
+The time.Time.String form, as stored in text columns by modernc.org/sqlite, is accepted too.
+
+Of[T].Value returns int64 for all integer types, so that the value is valid for any driver.
 */
 package nullable
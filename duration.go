@@ -0,0 +1,618 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISODuration is a time.Duration encoded to JSON as an ISO 8601 duration, e.g. "PT26H3M4.5S".
+// It is stored in the database as a time.Duration, and Of[ISODuration] is its nullable version.
+// Decoding accepts the formats of Of[time.Duration].
+type ISODuration time.Duration
+
+// StringDuration is a time.Duration encoded to JSON as a Go duration string, e.g. "26h3m4.5s".
+// It is stored in the database as a time.Duration, and Of[StringDuration] is its nullable version.
+// Decoding accepts the formats of Of[time.Duration].
+type StringDuration time.Duration
+
+// ErrInexactInterval is returned when an interval has a month or year part,
+// which has no fixed length and then can't be represented exactly as a time.Duration.
+var ErrInexactInterval = errors.New("month-based interval cannot be represented exactly as time.Duration")
+
+var intervalUnits = map[string]time.Duration{
+	"microsecond": time.Microsecond, "microseconds": time.Microsecond, "us": time.Microsecond,
+	"millisecond": time.Millisecond, "milliseconds": time.Millisecond, "ms": time.Millisecond,
+	"second": time.Second, "seconds": time.Second, "sec": time.Second, "secs": time.Second, "s": time.Second,
+	"minute": time.Minute, "minutes": time.Minute, "min": time.Minute, "mins": time.Minute, "m": time.Minute,
+	"hour": time.Hour, "hours": time.Hour, "hr": time.Hour, "hrs": time.Hour, "h": time.Hour,
+	"day": 24 * time.Hour, "days": 24 * time.Hour, "d": 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour, "w": 7 * 24 * time.Hour,
+}
+
+var intervalMonthUnits = map[string]bool{
+	"mon": true, "mons": true, "month": true, "months": true,
+	"year": true, "years": true, "yr": true, "yrs": true, "y": true,
+	"decade": true, "decades": true, "century": true, "centuries": true,
+	"millennium": true, "millennia": true,
+}
+
+// ParseInterval parses a PostgreSQL interval text output into a time.Duration.
+// The postgres ("1 day 02:03:04"), postgres_verbose ("@ 1 day 2 hours ago"),
+// sql_standard ("1 2:03:04") and iso_8601 ("P1DT2H3M4S") interval styles are supported.
+// A day is considered as 24 hours.
+// Intervals with a non-zero month or year part return an error wrapping ErrInexactInterval.
+func ParseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	var d time.Duration
+	var err error
+
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") || strings.HasPrefix(s, "+P") {
+		d, err = parseISO8601Duration(s)
+	} else {
+		d, err = parsePostgresInterval(s)
+	}
+
+	if err != nil {
+		return 0, &ParseError{Type: "time.Duration", Input: s, Err: err}
+	}
+
+	return d, nil
+}
+
+func parsePostgresInterval(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, errors.New("empty interval")
+	}
+
+	// In the sql_standard style, a sign on the leading field only applies to all the fields:
+	// -1 day -02:03:04 is written "-1 2:03:04".
+	if leadingSignOnly(fields) {
+		d, err := parsePostgresInterval(strings.Join(append([]string{fields[0][1:]}, fields[1:]...), " "))
+		if err != nil {
+			return 0, err
+		}
+
+		return -d, nil
+	}
+
+	var total time.Duration
+	ago := false
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		switch {
+		case field == "@":
+			continue
+		case field == "ago":
+			ago = true
+
+			continue
+		case strings.Contains(field, ":"):
+			d, err := parseClock(field)
+			if err != nil {
+				return 0, err
+			}
+
+			total, err = addDuration(total, d)
+			if err != nil {
+				return 0, err
+			}
+
+			continue
+		case isYearMonth(field):
+			if !isZeroNumber(field) {
+				return 0, ErrInexactInterval
+			}
+
+			continue
+		}
+
+		// A bare number is a number of days when followed by a time field (sql_standard style),
+		// seconds otherwise as PostgreSQL does on input.
+		unit := time.Second
+		if i+1 < len(fields) {
+			next := strings.ToLower(fields[i+1])
+			if strings.Contains(next, ":") {
+				unit = 24 * time.Hour
+			}
+
+			if intervalMonthUnits[next] {
+				if !isZeroNumber(field) {
+					return 0, ErrInexactInterval
+				}
+
+				i++
+
+				continue
+			}
+
+			if u, ok := intervalUnits[next]; ok {
+				unit = u
+				i++
+			}
+		}
+
+		d, err := parseFixed(field, unit)
+		if err != nil {
+			return 0, err
+		}
+
+		total, err = addDuration(total, d)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if ago {
+		total = -total
+	}
+
+	return total, nil
+}
+
+// leadingSignOnly reports whether fields are sql_standard fields, without units, of which only the leading one
+// is negative.
+func leadingSignOnly(fields []string) bool {
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "-") {
+		return false
+	}
+
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "-") || strings.HasPrefix(field, "+") {
+			return false
+		}
+	}
+
+	for _, field := range fields {
+		if strings.Trim(field, "+-0123456789.:") != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isYearMonth reports whether s is a sql_standard year-month field such as "1-2" or "-1-2".
+func isYearMonth(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	before, after, found := strings.Cut(s, "-")
+	if !found || before == "" || after == "" {
+		return false
+	}
+
+	for _, r := range before + after {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isZeroNumber reports whether s only holds zero digits, signs and separators.
+func isZeroNumber(s string) bool {
+	return strings.Trim(s, "+-.0") == ""
+}
+
+// parseClock parses a [+-]H:MM[:SS[.fffffffff]] time field.
+func parseClock(s string) (time.Duration, error) {
+	neg := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimLeft(s, "+-"), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time field %q", s)
+	}
+
+	hours, err := parseFixed(parts[0], time.Hour)
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := parseFixed(parts[1], time.Minute)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := addDuration(hours, minutes)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(parts) == 3 {
+		seconds, err := parseFixed(parts[2], time.Second)
+		if err != nil {
+			return 0, err
+		}
+
+		d, err = addDuration(d, seconds)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+func parseISO8601Duration(s string) (time.Duration, error) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+	s = strings.TrimPrefix(s, "P")
+	if s == "" {
+		return 0, errors.New("empty ISO 8601 duration")
+	}
+
+	var total time.Duration
+	inTime := false
+	timeComponents := 0
+
+	for s != "" {
+		if s[0] == 'T' {
+			if inTime {
+				return 0, errors.New("repeated ISO 8601 time designator")
+			}
+
+			inTime = true
+			s = s[1:]
+
+			continue
+		}
+
+		end := strings.IndexAny(s, "YMWDHS")
+		if end <= 0 {
+			return 0, fmt.Errorf("invalid ISO 8601 duration component %q", s)
+		}
+
+		if inTime {
+			timeComponents++
+		}
+
+		number, designator := s[:end], s[end]
+		s = s[end+1:]
+
+		var unit time.Duration
+
+		switch {
+		case designator == 'Y', designator == 'M' && !inTime:
+			if !isZeroNumber(number) {
+				return 0, ErrInexactInterval
+			}
+
+			continue
+		case designator == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case designator == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case designator == 'H' && inTime:
+			unit = time.Hour
+		case designator == 'M' && inTime:
+			unit = time.Minute
+		case designator == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("unexpected ISO 8601 designator %q", designator)
+		}
+
+		d, err := parseFixed(number, unit)
+		if err != nil {
+			return 0, err
+		}
+
+		total, err = addDuration(total, d)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if inTime && timeComponents == 0 {
+		return 0, errors.New("missing ISO 8601 time component")
+	}
+
+	if neg {
+		total = -total
+	}
+
+	return total, nil
+}
+
+// parseFixed parses a [+-]int[.frac] decimal number of the given unit.
+func parseFixed(s string, unit time.Duration) (time.Duration, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	neg := strings.HasPrefix(intPart, "-")
+	intPart = strings.TrimLeft(intPart, "+-")
+
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+
+	var d time.Duration
+
+	if intPart != "" {
+		i, err := strconv.ParseInt(intPart, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q : %w", s, err)
+		}
+
+		if i > math.MaxInt64/int64(unit) {
+			return 0, fmt.Errorf("%q overflows time.Duration", s)
+		}
+
+		d = time.Duration(i) * unit
+	}
+
+	if fracPart != "" {
+		if strings.Trim(fracPart, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+
+		f, err := strconv.ParseFloat("0."+fracPart, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q : %w", s, err)
+		}
+
+		d, err = addDuration(d, time.Duration(math.Round(f*float64(unit))))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+func addDuration(a, b time.Duration) (time.Duration, error) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, errors.New("interval overflows time.Duration")
+	}
+
+	return sum, nil
+}
+
+// FormatInterval formats a time.Duration as a PostgreSQL interval literal, e.g. "26:03:04.5".
+func FormatInterval(d time.Duration) string {
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign = "-"
+		u = -u
+	}
+
+	hours := u / uint64(time.Hour)
+	u -= hours * uint64(time.Hour)
+	minutes := u / uint64(time.Minute)
+	u -= minutes * uint64(time.Minute)
+	seconds := u / uint64(time.Second)
+	nanos := u - seconds*uint64(time.Second)
+
+	out := fmt.Sprintf("%s%d:%02d:%02d", sign, hours, minutes, seconds)
+	if nanos != 0 {
+		out += strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0")
+	}
+
+	return out
+}
+
+// FormatISO8601Duration formats a time.Duration as an ISO 8601 duration, e.g. "PT26H3M4.5S".
+func FormatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign = "-"
+		u = -u
+	}
+
+	hours := u / uint64(time.Hour)
+	u -= hours * uint64(time.Hour)
+	minutes := u / uint64(time.Minute)
+	u -= minutes * uint64(time.Minute)
+	seconds := u / uint64(time.Second)
+	nanos := u - seconds*uint64(time.Second)
+
+	var b strings.Builder
+	b.WriteString(sign + "PT")
+
+	if hours != 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+
+	if minutes != 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+
+	if seconds != 0 || nanos != 0 {
+		fmt.Fprintf(&b, "%d", seconds)
+
+		if nanos != 0 {
+			b.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0"))
+		}
+
+		b.WriteString("S")
+	}
+
+	return b.String()
+}
+
+func (n *Of[T]) scanDuration(v any) error {
+	if n == nil {
+		return errors.New("calling scanDuration on nil receiver")
+	}
+
+	switch value := v.(type) {
+	case int64:
+		n.SetValue(any(time.Duration(value)).(T))
+
+		return nil
+	case nil, string, []byte:
+	default:
+		return fmt.Errorf("nullable database scanning interval : cannot scan type %T", v)
+	}
+
+	null := sql.NullString{}
+	err := null.Scan(v)
+	if err != nil {
+		return fmt.Errorf("nullable database scanning interval : %w", err)
+	}
+
+	if null.Valid {
+		d, err := ParseInterval(null.String)
+		if err != nil {
+			return err
+		}
+
+		n.SetValue(any(d).(T))
+	} else {
+		n.SetNull()
+	}
+
+	return nil
+}
+
+// marshalDurationJSON encodes a time.Duration as a JSON number of nanoseconds, as encoding/json does.
+func marshalDurationJSON(d time.Duration) ([]byte, error) {
+	return json.Marshal(int64(d))
+}
+
+// unmarshalDurationJSON decodes a JSON number of nanoseconds,
+// a Go duration string or an ISO 8601 duration string.
+func (n *Of[T]) unmarshalDurationJSON(data []byte) error {
+	d, err := parseDurationJSON(data)
+	if err != nil {
+		return err
+	}
+
+	n.SetValue(any(d).(T))
+
+	return nil
+}
+
+// parseDurationJSON decodes a JSON number of nanoseconds,
+// a Go duration string or an ISO 8601 duration string.
+func parseDurationJSON(data []byte) (time.Duration, error) {
+	var s string
+
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		var ns int64
+
+		if json.Unmarshal(data, &ns) != nil {
+			return 0, fmt.Errorf("nullable Unmarshal Error : %w", err)
+		}
+
+		return time.Duration(ns), nil
+	}
+
+	if strings.HasPrefix(strings.TrimLeft(s, "+-"), "P") {
+		return ParseInterval(s)
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, &ParseError{Type: "time.Duration", Input: s, Err: err}
+	}
+
+	return d, nil
+}
+
+// scanDurationValue scans v as an Of[time.Duration] which must not be null.
+func scanDurationValue(v any) (time.Duration, error) {
+	var n Of[time.Duration]
+
+	err := n.Scan(v)
+	if err != nil {
+		return 0, err
+	}
+
+	if n.IsNull() {
+		return 0, errors.New("nullable database scanning interval : NULL value")
+	}
+
+	return *n.val, nil
+}
+
+// MarshalJSON implements the encoding json interface.
+func (d ISODuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FormatISO8601Duration(time.Duration(d)))
+}
+
+// UnmarshalJSON implements the decoding json interface.
+func (d *ISODuration) UnmarshalJSON(data []byte) error {
+	v, err := parseDurationJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*d = ISODuration(v)
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface, as Of[time.Duration] does.
+func (d ISODuration) Value() (driver.Value, error) {
+	return FormatInterval(time.Duration(d)), nil
+}
+
+// Scan implements the sql.Scanner interface, as Of[time.Duration] does.
+func (d *ISODuration) Scan(v any) error {
+	value, err := scanDurationValue(v)
+	if err != nil {
+		return err
+	}
+
+	*d = ISODuration(value)
+
+	return nil
+}
+
+// MarshalJSON implements the encoding json interface.
+func (d StringDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements the decoding json interface.
+func (d *StringDuration) UnmarshalJSON(data []byte) error {
+	v, err := parseDurationJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*d = StringDuration(v)
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface, as Of[time.Duration] does.
+func (d StringDuration) Value() (driver.Value, error) {
+	return FormatInterval(time.Duration(d)), nil
+}
+
+// Scan implements the sql.Scanner interface, as Of[time.Duration] does.
+func (d *StringDuration) Scan(v any) error {
+	value, err := scanDurationValue(v)
+	if err != nil {
+		return err
+	}
+
+	*d = StringDuration(value)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Enum is implemented by string-backed types restricted to a set of values,
+// typically mapped to a PostgreSQL enum type:
+//
+//	type Status string
+//
+//	func (Status) Values() []string { return []string{"active", "inactive"} }
+//
+// Of[Status] is then scanned and valued as a plain string instead of JSON,
+// and unknown values are rejected.
+type Enum interface {
+	// Values returns the allowed values.
+	Values() []string
+}
+
+// ErrInvalidEnumValue is returned when a value is not part of the values of an Enum.
+var ErrInvalidEnumValue = errors.New("value is not part of the enum values")
+
+// enumFromString validates s against the values of the Enum T and converts it to T.
+func enumFromString[T any](s string) (T, error) {
+	var out T
+
+	rv := reflect.ValueOf(&out).Elem()
+	if rv.Kind() != reflect.String {
+		return out, fmt.Errorf("enum type %T must have string as underlying type", out)
+	}
+
+	enum, _ := any(&out).(Enum)
+	if enum == nil || !slices.Contains(enum.Values(), s) {
+		return out, &ParseError{Type: fmt.Sprintf("%T", out), Input: s, Err: ErrInvalidEnumValue}
+	}
+
+	rv.SetString(s)
+
+	return out, nil
+}
+
+func (n *Of[T]) scanEnum(v any) error {
+	if n == nil {
+		return errors.New("calling scanEnum on nil receiver")
+	}
+
+	switch v.(type) {
+	case nil, string, []byte:
+	default:
+		return fmt.Errorf("nullable database scanning enum : cannot scan type %T", v)
+	}
+
+	null := sql.NullString{}
+	err := null.Scan(v)
+	if err != nil {
+		return fmt.Errorf("nullable database scanning enum : %w", err)
+	}
+
+	if null.Valid {
+		value, err := enumFromString[T](null.String)
+		if err != nil {
+			return err
+		}
+
+		n.SetValue(value)
+	} else {
+		n.SetNull()
+	}
+
+	return nil
+}
+
+// enumValue returns the string of an Enum for the driver.Valuer interface.
+func enumValue[T any](v *T) (driver.Value, error) {
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.String {
+		return nil, fmt.Errorf("enum type %T must have string as underlying type", *v)
+	}
+
+	_, err := enumFromString[T](rv.String())
+	if err != nil {
+		return nil, fmt.Errorf("nullable database value error : %w", err)
+	}
+
+	return rv.String(), nil
+}
+
+// unmarshalEnumJSON decodes a JSON string holding an Enum value.
+func (n *Of[T]) unmarshalEnumJSON(data []byte) error {
+	var s string
+
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return fmt.Errorf("nullable Unmarshal Error : %w", err)
+	}
+
+	value, err := enumFromString[T](s)
+	if err != nil {
+		return err
+	}
+
+	n.SetValue(value)
+
+	return nil
+}
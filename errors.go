@@ -0,0 +1,23 @@
+package nullable
+
+import "fmt"
+
+// ParseError is returned when a database or JSON input cannot be parsed into the nullable type.
+type ParseError struct {
+	// Type is the name of the Go type the input was parsed into.
+	Type string
+	// Input is the offending input.
+	Input string
+	// Err is the underlying parsing error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("nullable parsing %q as %s : %v", e.Input, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying parsing error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
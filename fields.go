@@ -0,0 +1,250 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrNotStruct is returned by Fields when the value is not a struct or a non-nil pointer to a struct.
+var ErrNotStruct = errors.New("not a struct")
+
+// Field describes a nullable field of a struct, that is a field whose pointer implements NullableI,
+// such as nullable.Of[T] or a type generated by nullgen.
+type Field struct {
+	// Name is the Go name of the field.
+	Name string
+	// Index is the index sequence of the field for reflect.Value.FieldByIndex,
+	// of length greater than 1 for the fields of embedded structs. It is shared by the results of Fields
+	// and must not be modified.
+	Index []int
+	// DBName and JSONName are the names of the db and json tags, without their options.
+	// They are empty if the field has no such tag, and "-" if the field is ignored.
+	DBName   string
+	JSONName string
+	// Type is the type of the field, such as nullable.Of[string], and ValueType the type of its value, such as string.
+	Type      reflect.Type
+	ValueType reflect.Type
+	// IsNull is true iff the value of the field is null.
+	IsNull bool
+	// Value is the value of the field, nil if null.
+	Value any
+}
+
+// Fields returns the nullable fields of v, which must be a struct or a pointer to a struct,
+// with the fields of the embedded structs, in the order of declaration.
+// The fields of an embedded pointer which is nil are null. The layout of each struct type is cached.
+func Fields(v any) ([]Field, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("nullable fields of a nil %T : %w", v, ErrNotStruct)
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullable fields of %T : %w", v, ErrNotStruct)
+	}
+
+	if !rv.CanAddr() {
+		// The methods of the fields have pointer receivers
+		addressable := reflect.New(rv.Type()).Elem()
+		addressable.Set(rv)
+		rv = addressable
+	}
+
+	layout := fieldsLayout(rv.Type())
+	fields := make([]Field, len(layout))
+
+	for i, f := range layout {
+		fields[i] = f
+		fields[i].IsNull = true
+
+		fv, err := rv.FieldByIndexErr(f.Index)
+		if err != nil {
+			// Field of a nil embedded pointer
+			continue
+		}
+
+		p := fv.Addr()
+		if p.Interface().(interface{ IsNull() bool }).IsNull() {
+			continue
+		}
+
+		fields[i].IsNull = false
+		fields[i].Value = p.MethodByName("GetValue").Call(nil)[0].Elem().Interface()
+	}
+
+	return fields, nil
+}
+
+// structFields is the cache of the layouts of the nullable fields by struct type.
+var structFields sync.Map // map[reflect.Type][]Field
+
+// fieldsLayout returns the nullable fields of the struct type t, without their values.
+func fieldsLayout(t reflect.Type) []Field {
+	if cached, ok := structFields.Load(t); ok {
+		return cached.([]Field)
+	}
+
+	var fields []Field
+
+	depths := map[string][]int{}
+
+	WalkFields(t, func(sf reflect.StructField) bool {
+		depths[sf.Name] = append(depths[sf.Name], len(sf.Index))
+
+		if valueType, ok := nullableValueType(sf.Type); ok {
+			if sf.IsExported() {
+				fields = append(fields, Field{
+					Name:      sf.Name,
+					Index:     sf.Index,
+					DBName:    tagName(sf.Tag.Get("db")),
+					JSONName:  tagName(sf.Tag.Get("json")),
+					Type:      sf.Type,
+					ValueType: valueType,
+				})
+			}
+
+			return false
+		}
+
+		// An unexported embedded pointer is not settable through reflection, as with encoding/json
+		return sf.Type.Kind() != reflect.Pointer || sf.IsExported()
+	})
+
+	// As in Go, a field hides the fields of the same name of the embedded structs,
+	// and the fields of the same name at the same depth hide each other.
+	layout := make([]Field, 0, len(fields))
+
+	for _, f := range fields {
+		shallowest := slices.Min(depths[f.Name])
+		if len(f.Index) == shallowest && countDepth(depths[f.Name], shallowest) == 1 {
+			layout = append(layout, f)
+		}
+	}
+
+	cached, _ := structFields.LoadOrStore(t, layout)
+
+	return cached.([]Field)
+}
+
+// countDepth returns the number of occurrences of depth in depths.
+func countDepth(depths []int, depth int) int {
+	count := 0
+
+	for _, d := range depths {
+		if d == depth {
+			count++
+		}
+	}
+
+	return count
+}
+
+// WalkFields calls visit with the fields of the struct type t in the order of declaration, the Index of each field
+// being its index sequence in t for reflect.Value.FieldByIndex. The embedded structs, or pointers to structs,
+// for which visit returns true are walked. The struct types being walked are embedding,
+// so that a type embedding a pointer to itself is walked once.
+func WalkFields(t reflect.Type, visit func(sf reflect.StructField) bool) {
+	walkFields(t, nil, visit, map[reflect.Type]bool{t: true})
+}
+
+func walkFields(t reflect.Type, index []int, visit func(reflect.StructField) bool, embedding map[reflect.Type]bool) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		sf.Index = append(index[:len(index):len(index)], i)
+
+		if !visit(sf) || !sf.Anonymous {
+			continue
+		}
+
+		embedded := sf.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+
+		if embedded.Kind() == reflect.Struct && !embedding[embedded] {
+			embedding[embedded] = true
+			walkFields(embedded, sf.Index, visit, embedding)
+			delete(embedding, embedded)
+		}
+	}
+}
+
+// tagName returns the name of the struct tag value tag, without its options.
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name
+}
+
+// nullableValueType returns T if the pointers to t implement NullableI[T].
+func nullableValueType(t reflect.Type) (reflect.Type, bool) {
+	pt := reflect.PointerTo(t)
+
+	get, ok := pt.MethodByName("GetValue")
+	if !ok || get.Type.NumIn() != 1 || get.Type.NumOut() != 1 || get.Type.Out(0).Kind() != reflect.Pointer {
+		return nil, false
+	}
+
+	elem := get.Type.Out(0).Elem()
+
+	var (
+		anyType    = reflect.TypeFor[any]()
+		boolType   = reflect.TypeFor[bool]()
+		bytesType  = reflect.TypeFor[[]byte]()
+		errorType  = reflect.TypeFor[error]()
+		driverType = reflect.TypeFor[driver.Value]()
+	)
+
+	methods := []struct {
+		name    string
+		in, out []reflect.Type
+	}{
+		{"IsNull", nil, []reflect.Type{boolType}},
+		{"SetValue", []reflect.Type{elem}, nil},
+		{"SetValueP", []reflect.Type{reflect.PointerTo(elem)}, nil},
+		{"SetNull", nil, nil},
+		{"MarshalJSON", nil, []reflect.Type{bytesType, errorType}},
+		{"UnmarshalJSON", []reflect.Type{bytesType}, []reflect.Type{errorType}},
+		{"Value", nil, []reflect.Type{driverType, errorType}},
+		{"Scan", []reflect.Type{anyType}, []reflect.Type{errorType}},
+	}
+
+	for _, m := range methods {
+		method, ok := pt.MethodByName(m.name)
+		if !ok || !sameSignature(method.Type, m.in, m.out) {
+			return nil, false
+		}
+	}
+
+	return elem, true
+}
+
+// sameSignature returns true iff the method type f, with its receiver, has the parameters in and the results out.
+func sameSignature(f reflect.Type, in, out []reflect.Type) bool {
+	if f.NumIn() != len(in)+1 || f.NumOut() != len(out) {
+		return false
+	}
+
+	for i, t := range in {
+		if f.In(i+1) != t {
+			return false
+		}
+	}
+
+	for i, t := range out {
+		if f.Out(i) != t {
+			return false
+		}
+	}
+
+	return true
+}
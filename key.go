@@ -0,0 +1,107 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"net/netip"
+	"reflect"
+	"time"
+)
+
+// jsonKey is the Key of a value which is not comparable: its canonical JSON.
+// It is a distinct type so that it is never equal to the Key of a string value.
+type jsonKey string
+
+// Key returns a comparable representation of n, such that two values have the same Key
+// if and only if they are equal as defined by Equal. It permits to use nullable values as map keys:
+//
+//	seen := map[any]struct{}{}
+//	seen[n.Key()] = struct{}{}
+//
+// The Key of a null is nil. The Key of a scalar value, such as a number, a string, an uuid.UUID,
+// a netip.Addr or a Date, is the value itself, a time.Time being converted to UTC
+// so that equal instants have the same Key. The Key of other values, such as JSON payloads,
+// is their canonical JSON: the JSON with sorted object keys and without insignificant spaces.
+func (n Of[T]) Key() any {
+	if n.IsNull() {
+		return nil
+	}
+
+	switch v := any(*n.val).(type) {
+	case time.Time:
+		return v.UTC()
+	case netip.Addr, netip.Prefix, Date, TimeOfDay:
+		return v
+	case nil:
+		return jsonKey("null")
+	default:
+		if isScalar(reflect.TypeOf(v)) {
+			return v
+		}
+
+		return jsonKey(canonicalJSON(v))
+	}
+}
+
+// isScalar returns true iff the values of t are compared by value with ==,
+// that is t is a basic type or an array of basic types such as uuid.UUID.
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isScalar(t.Elem())
+	}
+
+	return false
+}
+
+// Equal returns true iff n and other are both null or hold equal values.
+// Unlike ==, which compares pointers, it compares the values:
+// time.Time values are equal if they are the same instant,
+// and the other values which are not scalar, such as JSON payloads, are equal if their canonical JSON are equal.
+// Unlike the Equal function, a null is equal to a null, as with the SQL IS NOT DISTINCT FROM.
+//
+// It permits to remove consecutive duplicates with slices.CompactFunc(values, nullable.Of[T].Equal).
+func (n Of[T]) Equal(other Of[T]) bool {
+	return n.Key() == other.Key()
+}
+
+// Hash returns the hash of n with the given seed, consistent with Equal:
+// equal values have the same hash.
+func (n Of[T]) Hash(seed maphash.Seed) uint64 {
+	return maphash.Comparable(seed, n.Key())
+}
+
+// canonicalJSON returns the JSON of v with sorted object keys and without insignificant spaces.
+// Values which can't be encoded to JSON are represented by their Go syntax.
+func canonicalJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+
+	// Decoding to any then encoding again sorts the keys of all objects,
+	// including those encoded by MarshalJSON methods.
+	var decoded any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&decoded)
+	if err != nil {
+		return string(data)
+	}
+
+	data, err = json.Marshal(decoded)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,94 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// kinds caches the underlying primitive kind of the types handled by Of, see underlyingKind.
+var kinds sync.Map // map[reflect.Type]reflect.Kind
+
+// underlyingKind returns the kind of T if it is a named type, such as type UserID int64,
+// whose underlying type is a supported primitive. It returns reflect.Invalid otherwise.
+// The result is computed once per type.
+func underlyingKind[T any]() reflect.Kind {
+	t := reflect.TypeFor[T]()
+
+	if kind, ok := kinds.Load(t); ok {
+		return kind.(reflect.Kind)
+	}
+
+	kind := reflect.Invalid
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float64, reflect.String:
+		kind = t.Kind()
+	}
+
+	kinds.Store(t, kind)
+
+	return kind
+}
+
+// scanUnderlying scans v as the underlying primitive type of T.
+func (n *Of[T]) scanUnderlying(kind reflect.Kind, v any) error {
+	switch kind {
+	case reflect.Bool:
+		return scanConverted[bool](n, v)
+	case reflect.Int:
+		return scanConverted[int](n, v)
+	case reflect.Int16:
+		return scanConverted[int16](n, v)
+	case reflect.Int32:
+		return scanConverted[int32](n, v)
+	case reflect.Int64:
+		return scanConverted[int64](n, v)
+	case reflect.Float64:
+		return scanConverted[float64](n, v)
+	case reflect.String:
+		return scanConverted[string](n, v)
+	}
+
+	return fmt.Errorf("type %T is not supported", *new(T))
+}
+
+// scanConverted scans v into an Of[B] then converts the result to T.
+func scanConverted[B bool | int | int16 | int32 | int64 | string | float64, T any](n *Of[T], v any) error {
+	base := Of[B]{}
+
+	err := base.Scan(v)
+	if err != nil {
+		return err
+	}
+
+	if base.IsNull() {
+		n.SetNull()
+
+		return nil
+	}
+
+	converted := reflect.ValueOf(*base.val).Convert(reflect.TypeFor[T]())
+	n.SetValue(converted.Interface().(T))
+
+	return nil
+}
+
+// underlyingValue returns the value of *v as its underlying primitive type for the driver.Valuer interface.
+func underlyingValue[T any](kind reflect.Kind, v *T) (driver.Value, error) {
+	rv := reflect.ValueOf(v).Elem()
+
+	switch kind {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	}
+
+	return nil, fmt.Errorf("type %T is not supported for value %v", *v, *v)
+}
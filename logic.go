@@ -0,0 +1,63 @@
+package nullable
+
+// The functions of this file implement the three-valued logic of SQL (Kleene logic) on Of[bool],
+// where null means unknown. They give the same results as the SQL expressions,
+// which permits to filter in memory as a WHERE clause would.
+
+// And returns the SQL a AND b: false if any operand is false, else null if any operand is null.
+func And(a, b Of[bool]) Of[bool] {
+	switch {
+	case isFalse(a) || isFalse(b):
+		return FromValue(false)
+	case a.IsNull() || b.IsNull():
+		return Null[bool]()
+	}
+
+	return FromValue(true)
+}
+
+// Or returns the SQL a OR b: true if any operand is true, else null if any operand is null.
+func Or(a, b Of[bool]) Of[bool] {
+	switch {
+	case IsTrue(a) || IsTrue(b):
+		return FromValue(true)
+	case a.IsNull() || b.IsNull():
+		return Null[bool]()
+	}
+
+	return FromValue(false)
+}
+
+// Not returns the SQL NOT a: null if a is null.
+func Not(a Of[bool]) Of[bool] {
+	if a.IsNull() {
+		return Null[bool]()
+	}
+
+	return FromValue(!*a.val)
+}
+
+// Xor returns the exclusive or of a and b, the SQL a <> b on booleans: null if any operand is null.
+func Xor(a, b Of[bool]) Of[bool] {
+	if a.IsNull() || b.IsNull() {
+		return Null[bool]()
+	}
+
+	return FromValue(*a.val != *b.val)
+}
+
+// IsTrue returns the SQL a IS TRUE: false if a is null.
+// It is the condition for a row to be selected by a WHERE clause.
+func IsTrue(a Of[bool]) bool {
+	return !a.IsNull() && *a.val
+}
+
+// IsNotFalse returns the SQL a IS NOT FALSE: true if a is null.
+// It is the condition for a row to be accepted by a CHECK constraint.
+func IsNotFalse(a Of[bool]) bool {
+	return !isFalse(a)
+}
+
+func isFalse(a Of[bool]) bool {
+	return !a.IsNull() && !*a.val
+}
@@ -0,0 +1,179 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// parseAddr parses a PostgreSQL inet text output into a netip.Addr.
+// A netmask is only accepted if it denotes a single host (e.g. /32 or /128).
+func parseAddr(s string) (netip.Addr, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Addr{}, &ParseError{Type: "netip.Addr", Input: s, Err: err}
+		}
+
+		return addr, nil
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Addr{}, &ParseError{Type: "netip.Addr", Input: s, Err: err}
+	}
+
+	if prefix.Bits() != prefix.Addr().BitLen() {
+		return netip.Addr{}, &ParseError{
+			Type:  "netip.Addr",
+			Input: s,
+			Err:   errors.New("netmask does not denote a single host, use netip.Prefix instead"),
+		}
+	}
+
+	return prefix.Addr(), nil
+}
+
+// parsePrefix parses a PostgreSQL inet or cidr text output into a netip.Prefix.
+// A host address without netmask is given the full-length prefix.
+func parsePrefix(s string) (netip.Prefix, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, &ParseError{Type: "netip.Prefix", Input: s, Err: err}
+		}
+
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, &ParseError{Type: "netip.Prefix", Input: s, Err: err}
+	}
+
+	return prefix, nil
+}
+
+// parseHardwareAddr parses a PostgreSQL macaddr or macaddr8 text output into a net.HardwareAddr.
+func parseHardwareAddr(s string) (net.HardwareAddr, error) {
+	hw, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, &ParseError{Type: "net.HardwareAddr", Input: s, Err: err}
+	}
+
+	return hw, nil
+}
+
+func (n *Of[T]) scanAddr(v any) error {
+	if n == nil {
+		return errors.New("calling scanAddr on nil receiver")
+	}
+
+	null := sql.NullString{}
+	err := null.Scan(v)
+	if err != nil {
+		return fmt.Errorf("nullable database scanning inet : %w", err)
+	}
+
+	if null.Valid {
+		addr, err := parseAddr(null.String)
+		if err != nil {
+			return err
+		}
+
+		n.SetValue(any(addr).(T))
+	} else {
+		n.SetNull()
+	}
+
+	return nil
+}
+
+func (n *Of[T]) scanPrefix(v any) error {
+	if n == nil {
+		return errors.New("calling scanPrefix on nil receiver")
+	}
+
+	null := sql.NullString{}
+	err := null.Scan(v)
+	if err != nil {
+		return fmt.Errorf("nullable database scanning cidr : %w", err)
+	}
+
+	if null.Valid {
+		prefix, err := parsePrefix(null.String)
+		if err != nil {
+			return err
+		}
+
+		n.SetValue(any(prefix).(T))
+	} else {
+		n.SetNull()
+	}
+
+	return nil
+}
+
+func (n *Of[T]) scanHardwareAddr(v any) error {
+	if n == nil {
+		return errors.New("calling scanHardwareAddr on nil receiver")
+	}
+
+	null := sql.NullString{}
+	err := null.Scan(v)
+	if err != nil {
+		return fmt.Errorf("nullable database scanning macaddr : %w", err)
+	}
+
+	if null.Valid {
+		hw, err := parseHardwareAddr(null.String)
+		if err != nil {
+			return err
+		}
+
+		n.SetValue(any(hw).(T))
+	} else {
+		n.SetNull()
+	}
+
+	return nil
+}
+
+// netValue returns the canonical string of a network address for the driver.Valuer interface.
+func netValue(v any) (driver.Value, error) {
+	switch value := v.(type) {
+	case *netip.Addr:
+		if !value.IsValid() {
+			return nil, errors.New("nullable database value error : zero netip.Addr")
+		}
+
+		return value.String(), nil
+	case *netip.Prefix:
+		if !value.IsValid() {
+			return nil, errors.New("nullable database value error : zero netip.Prefix")
+		}
+
+		return value.String(), nil
+	case *net.HardwareAddr:
+		return value.String(), nil
+	}
+
+	return nil, fmt.Errorf("type %T is not a network address", v)
+}
+
+// unmarshalNetJSON decodes a JSON string holding a network address.
+func (n *Of[T]) unmarshalNetJSON(data []byte) error {
+	var s string
+
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return fmt.Errorf("nullable Unmarshal Error : %w", err)
+	}
+
+	return n.Scan(s)
+}
@@ -107,6 +107,17 @@ func (n *Of[T]) scanUUID(v any) error {
 		return errors.New("calling scanUUID on nil receiver")
 	}
 
+	if b, ok := v.([]byte); ok && len(b) == 16 {
+		uid, err := uuid.FromBytes(b)
+		if err != nil {
+			return fmt.Errorf("UUID parsing failed : %w", err)
+		}
+
+		n.SetValue(any(uid).(T))
+
+		return nil
+	}
+
 	null := sql.NullString{}
 	err := null.Scan(v)
 	if err != nil {
@@ -184,6 +195,20 @@ func (n *Of[T]) scanInt(v any) error {
 			n.SetNull()
 		}
 
+		return nil
+	case uint8, *uint8:
+		null := new(sql.NullByte)
+		err := null.Scan(v)
+		if err != nil {
+			return fmt.Errorf("nullable database scanning uint8 : %w", err)
+		}
+
+		if null.Valid {
+			n.SetValue(any(null.Byte).(T))
+		} else {
+			n.SetNull()
+		}
+
 		return nil
 	}
 
@@ -232,19 +257,22 @@ func (n *Of[T]) scanTime(v any) error {
 	null := new(sql.NullTime)
 
 	switch t := v.(type) {
-	case string:
-		var err error
-		null.Time, err = time.Parse(t, t)
-		if err != nil {
-			return fmt.Errorf("%w", err)
+	case string, []byte:
+		s := asString(t)
+
+		parsed, ok := parseTimestamp(s)
+		if !ok {
+			return &ParseError{Type: "time.Time", Input: s, Err: errors.New("unknown timestamp layout")}
 		}
+
+		null.Time, null.Valid = parsed, true
 	case time.Time:
 		err := null.Scan(v)
 		if err != nil {
 			return fmt.Errorf("nullable database scanning Time : %w", err)
 		}
 	default:
-		return fmt.Errorf("canot parse type \"%T\" with value \"%v\" to time", t, t)
+		return fmt.Errorf("cannot parse type \"%T\" with value \"%v\" to time", t, t)
 	}
 
 	if null.Valid {
@@ -0,0 +1,227 @@
+/*
+Package nullabletest provides an in-memory database/sql driver to unit-test code using nullable.Of
+without a database server.
+
+Rows are declared as Go values, including the driver-specific forms real drivers return,
+such as []byte timestamps or int64 booleans, and are passed as is to the Scan methods.
+The driver.Value arguments of Exec are recorded after the database/sql conversions,
+so that they are the values a real driver would receive:
+
+	db := nullabletest.New()
+	db.AddRows("SELECT name, active FROM users", []string{"name", "active"},
+		[]any{[]byte("Alice"), int64(1)},
+		[]any{nil, int64(0)},
+	)
+
+	repo := NewRepository(db.DB())
+	...
+
+	for _, exec := range db.Execs() {
+		t.Log(exec.Query, exec.Args)
+	}
+
+Queries are matched on their text, all sequences of white spaces being considered equal.
+A query without declared rows returns an error.
+*/
+package nullabletest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Exec is a statement executed through the driver.
+type Exec struct {
+	// Query is the text of the statement.
+	Query string
+	// Args are the values received by the driver.
+	Args []driver.Value
+}
+
+// rows are the declared results of a query.
+type rows struct {
+	columns []string
+	values  [][]any
+}
+
+// DB is an in-memory database. It implements driver.Connector.
+// It is safe for concurrent use.
+type DB struct {
+	mu      sync.Mutex
+	results map[string]rows
+	execs   []Exec
+}
+
+// New returns an empty in-memory database.
+func New() *DB {
+	return &DB{results: map[string]rows{}}
+}
+
+// DB returns a *sql.DB using the in-memory database.
+func (db *DB) DB() *sql.DB {
+	return sql.OpenDB(db)
+}
+
+// AddRows declares the result of query. Each row holds one value per column,
+// values are given as is to the sql.Scanner of the destinations.
+// Declaring the rows of a query again replaces them.
+func (db *DB) AddRows(query string, columns []string, values ...[]any) {
+	for i, row := range values {
+		if len(row) != len(columns) {
+			panic(fmt.Sprintf("nullabletest: row %d has %d values for %d columns", i, len(row), len(columns)))
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.results[normalize(query)] = rows{columns: columns, values: values}
+}
+
+// Execs returns the statements executed so far, in order.
+func (db *DB) Execs() []Exec {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return slices.Clone(db.execs)
+}
+
+// Reset forgets the declared rows and the executed statements.
+func (db *DB) Reset() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.results = map[string]rows{}
+	db.execs = nil
+}
+
+// Connect implements the driver.Connector interface.
+func (db *DB) Connect(context.Context) (driver.Conn, error) {
+	return &conn{db: db}, nil
+}
+
+// Driver implements the driver.Connector interface.
+func (db *DB) Driver() driver.Driver {
+	return fakeDriver{db: db}
+}
+
+func (db *DB) exec(query string, args []driver.Value) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.execs = append(db.execs, Exec{Query: query, Args: slices.Clone(args)})
+}
+
+func (db *DB) query(query string) (*resultRows, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	result, ok := db.results[normalize(query)]
+	if !ok {
+		return nil, fmt.Errorf("nullabletest: no rows declared for query %q", query)
+	}
+
+	return &resultRows{rows: result}, nil
+}
+
+// normalize replaces all sequences of white spaces of query by a single space.
+func normalize(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+type fakeDriver struct {
+	db *DB
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return &conn{db: d.db}, nil
+}
+
+type conn struct {
+	db *DB
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{db: c.db, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+type stmt struct {
+	db    *DB
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1: the number of placeholders isn't checked.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+// Exec records the statement and reports one affected row.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.exec(s.query, args)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stmt) Query([]driver.Value) (driver.Rows, error) {
+	return s.db.query(s.query)
+}
+
+type resultRows struct {
+	rows
+	next int
+}
+
+func (r *resultRows) Columns() []string {
+	return r.columns
+}
+
+func (r *resultRows) Close() error {
+	return nil
+}
+
+func (r *resultRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+
+	if len(dest) != len(r.columns) {
+		return errors.New("nullabletest: wrong number of destinations")
+	}
+
+	for i, value := range r.values[r.next] {
+		dest[i] = value
+	}
+
+	r.next++
+
+	return nil
+}
@@ -0,0 +1,311 @@
+package nullsql
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CopyFormat is a format of the PostgreSQL statement COPY.
+type CopyFormat int
+
+const (
+	// CopyText is the text format, the default one of COPY.
+	CopyText CopyFormat = iota
+	// CopyCSV is the CSV format.
+	CopyCSV
+)
+
+// copyNull is the representation of the null values, in both formats.
+const copyNull = `\N`
+
+// CopySource is a source of rows of structs for the bulk loading of PostgreSQL by COPY FROM.
+// It implements pgx.CopyFromSource, without depending on pgx:
+//
+//	src, err := nullsql.CopyFrom(users)
+//	count, err := conn.CopyFrom(ctx, pgx.Identifier{"users"}, src.Columns(), src)
+//
+// The values of the rows are the results of the method Value of the fields implementing driver.Valuer,
+// and the values of the other fields, converted to driver values.
+type CopySource struct {
+	columns []column
+	next    func() (reflect.Value, bool)
+	stop    func()
+	row     int
+	values  []any
+	err     error
+}
+
+// CopyFrom returns the source of the rows, a slice or an array of structs or pointers to structs.
+func CopyFrom(rows any) (*CopySource, error) {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("nullsql: the rows %T are not a slice", rows)
+	}
+
+	i := 0
+
+	return newCopySource(rv.Type().Elem(), func() (reflect.Value, bool) {
+		if i == rv.Len() {
+			return reflect.Value{}, false
+		}
+
+		i++
+
+		return rv.Index(i - 1), true
+	}, func() {})
+}
+
+// CopyFromSeq returns the source of the rows of the sequence, structs or pointers to structs.
+// The source must be closed if it is not read to the end.
+func CopyFromSeq[T any](rows iter.Seq[T]) (*CopySource, error) {
+	next, stop := iter.Pull(rows)
+
+	src, err := newCopySource(reflect.TypeFor[T](), func() (reflect.Value, bool) {
+		row, ok := next()
+
+		return reflect.ValueOf(&row).Elem(), ok
+	}, stop)
+	if err != nil {
+		stop()
+
+		return nil, err
+	}
+
+	return src, nil
+}
+
+// newCopySource returns the source of the rows of type elem returned by next, stop releasing them.
+func newCopySource(elem reflect.Type, next func() (reflect.Value, bool), stop func()) (*CopySource, error) {
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullsql: the rows of type %s are not structs", elem)
+	}
+
+	l := layoutOf(elem)
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	if len(l.columns) == 0 {
+		return nil, fmt.Errorf("nullsql: copy of %s : %w", elem, ErrNoColumns)
+	}
+
+	return &CopySource{columns: l.columns, next: next, stop: stop}, nil
+}
+
+// Columns returns the names of the columns, in the order of the values.
+func (s *CopySource) Columns() []string {
+	names := make([]string, len(s.columns))
+	for i, c := range s.columns {
+		names[i] = c.name
+	}
+
+	return names
+}
+
+// Statement returns the statement COPY FROM STDIN of the columns into the table in the format.
+func (s *CopySource) Statement(table string, format CopyFormat) string {
+	statement := "COPY " + table + " (" + strings.Join(s.Columns(), ", ") + ") FROM STDIN"
+	if format == CopyCSV {
+		statement += ` WITH (FORMAT csv, NULL '\N')`
+	}
+
+	return statement
+}
+
+// Next advances to the next row and returns true if there is one.
+func (s *CopySource) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	row, ok := s.next()
+	if !ok {
+		s.Close()
+
+		return false
+	}
+
+	values, err := copyValues(row, s.columns)
+	if err != nil {
+		s.err = fmt.Errorf("nullsql: row %d : %w", s.row, err)
+		s.Close()
+
+		return false
+	}
+
+	s.values = values
+	s.row++
+
+	return true
+}
+
+// Values returns the values of the current row, nil for the null values.
+func (s *CopySource) Values() ([]any, error) {
+	return s.values, nil
+}
+
+// Err returns the error which stopped the reading of the rows, if any.
+func (s *CopySource) Err() error {
+	return s.err
+}
+
+// Close releases the sequence of the rows.
+func (s *CopySource) Close() {
+	s.stop()
+}
+
+// copyValues returns the driver values of the columns of the row.
+func copyValues(row reflect.Value, columns []column) ([]any, error) {
+	values, err := rowValues(row, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range values {
+		values[i], err = driver.DefaultParameterConverter.ConvertValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("value of the column %s : %w", columns[i].name, err)
+		}
+
+		if b, ok := values[i].([]byte); ok && b == nil {
+			// As for the drivers, a nil []byte is null
+			values[i] = nil
+		}
+	}
+
+	return values, nil
+}
+
+// WriteCopy writes the rows of src to w in the format, as expected by the statement returned by src.Statement.
+// The null values are written \N.
+func WriteCopy(w io.Writer, src *CopySource, format CopyFormat) error {
+	bw := bufio.NewWriter(w)
+
+	for src.Next() {
+		for i, v := range src.values {
+			if i > 0 {
+				if format == CopyCSV {
+					bw.WriteByte(',')
+				} else {
+					bw.WriteByte('\t')
+				}
+			}
+
+			if v == nil {
+				bw.WriteString(copyNull)
+
+				continue
+			}
+
+			text := copyText(v)
+			if format == CopyCSV {
+				bw.WriteString(csvField(text))
+			} else {
+				bw.WriteString(textField(text))
+			}
+		}
+
+		bw.WriteByte('\n')
+	}
+
+	if err := src.Err(); err != nil {
+		return err
+	}
+
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("nullsql: writing copy data : %w", err)
+	}
+
+	return nil
+}
+
+// copyText returns the representation of the driver value v in the input of PostgreSQL.
+func copyText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return `\x` + hex.EncodeToString(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		case math.IsNaN(v):
+			return "NaN"
+		}
+
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		if v {
+			return "t"
+		}
+
+		return "f"
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999999Z07:00")
+	}
+
+	return fmt.Sprint(v)
+}
+
+// textField escapes the text for the text format: the backslashes and the control characters
+// are escaped, so that \N is the null value only.
+func textField(text string) string {
+	if !strings.ContainsAny(text, "\\\n\r\t\b\f\v") {
+		return text
+	}
+
+	var b strings.Builder
+
+	for _, r := range text {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\v':
+			b.WriteString(`\v`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// csvField quotes the text for the CSV format if it contains a special character, could be read as null,
+// or is the end-of-data marker \. which ends the data when it is alone on its line.
+func csvField(text string) string {
+	if text != copyNull && text != `\.` && text != "" && !strings.ContainsAny(text, ",\"\r\n") &&
+		strings.TrimSpace(text) == text {
+		return text
+	}
+
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+}
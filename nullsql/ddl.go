@@ -0,0 +1,322 @@
+package nullsql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+)
+
+// Dialect is the SQL dialect of the generated DDL.
+type Dialect int
+
+const (
+	// Postgres is the dialect of PostgreSQL.
+	Postgres Dialect = iota
+	// SQLite is the dialect of SQLite.
+	SQLite
+)
+
+// ErrNoSQLType is returned when the SQL type of a column can't be derived from its Go type.
+var ErrNoSQLType = errors.New("no SQL type for the Go type, set it with the ddl tag")
+
+// CreateTableOptions are the options of CreateTable.
+type CreateTableOptions struct {
+	// Dialect is the dialect of the statement, Postgres by default.
+	Dialect Dialect
+	// IfNotExists adds IF NOT EXISTS to the statement.
+	IfNotExists bool
+}
+
+// columnDDL are the overrides of the ddl tag of a column.
+type columnDDL struct {
+	sqlType, postgresType, sqliteType string
+	defaultValue, constraints         string
+}
+
+// sqlTypes are the SQL types of the Go types, for PostgreSQL and SQLite.
+var sqlTypes = map[reflect.Type][2]string{
+	reflect.TypeFor[bool]():                    {"BOOLEAN", "BOOLEAN"},
+	reflect.TypeFor[int]():                     {"BIGINT", "BIGINT"},
+	reflect.TypeFor[int16]():                   {"SMALLINT", "SMALLINT"},
+	reflect.TypeFor[int32]():                   {"INTEGER", "INTEGER"},
+	reflect.TypeFor[int64]():                   {"BIGINT", "BIGINT"},
+	reflect.TypeFor[uint8]():                   {"SMALLINT", "SMALLINT"},
+	reflect.TypeFor[float64]():                 {"DOUBLE PRECISION", "DOUBLE PRECISION"},
+	reflect.TypeFor[string]():                  {"TEXT", "TEXT"},
+	reflect.TypeFor[[]byte]():                  {"BYTEA", "BLOB"},
+	reflect.TypeFor[uuid.UUID]():               {"UUID", "TEXT"},
+	reflect.TypeFor[time.Time]():               {"TIMESTAMP", "TIMESTAMP"},
+	reflect.TypeFor[time.Duration]():           {"INTERVAL", "TEXT"},
+	reflect.TypeFor[nullable.ISODuration]():    {"INTERVAL", "TEXT"},
+	reflect.TypeFor[nullable.StringDuration](): {"INTERVAL", "TEXT"},
+	reflect.TypeFor[nullable.Date]():           {"DATE", "DATE"},
+	reflect.TypeFor[nullable.TimeOfDay]():      {"TIME", "TIME"},
+	reflect.TypeFor[netip.Addr]():              {"INET", "TEXT"},
+	reflect.TypeFor[netip.Prefix]():            {"CIDR", "TEXT"},
+	reflect.TypeFor[net.HardwareAddr]():        {"MACADDR", "TEXT"},
+}
+
+// basicTypes are the supported types of the underlying types of the named types, by kind.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeFor[bool](),
+	reflect.Int:     reflect.TypeFor[int](),
+	reflect.Int16:   reflect.TypeFor[int16](),
+	reflect.Int32:   reflect.TypeFor[int32](),
+	reflect.Int64:   reflect.TypeFor[int64](),
+	reflect.Float64: reflect.TypeFor[float64](),
+	reflect.String:  reflect.TypeFor[string](),
+}
+
+// CreateTable returns the CREATE TABLE statement of the table whose columns are the columns of the struct type of v,
+// a struct or a pointer to a struct, possibly nil.
+//
+// The columns of the nullable fields, such as nullable.Of[T], of the pointers and of the fields of the embedded
+// pointers are nullable, the other ones are NOT NULL. The SQL type is derived from the Go type of the value:
+// nullable.Of[int16] is a SMALLINT, nullable.Of[uuid.UUID] a UUID, nullable.Of[nullable.JSON] a JSONB,
+// the enums are TEXT with a CHECK constraint on their values, and so on (see README.md).
+//
+// The ddl tag overrides the type of the column, for all the dialects or for one of them, sets its default value
+// and appends constraints, its keys and values being separated by semicolons:
+//
+//	ID    int64               `db:"id" ddl:"type:BIGSERIAL;sqlite_type:INTEGER;constraints:PRIMARY KEY"`
+//	Name  nullable.Of[string] `db:"name" ddl:"type:VARCHAR(255)"`
+//	Count int                 `db:"count" ddl:"default:0;constraints:CHECK (count >= 0)"`
+func CreateTable(table string, v any, opts CreateTableOptions) (string, error) {
+	t, l, err := structType(v)
+	if err != nil {
+		return "", err
+	}
+
+	if len(l.columns) == 0 {
+		return "", fmt.Errorf("nullsql: create table %s : %w", table, ErrNoColumns)
+	}
+
+	valueTypes, err := nullableValueTypes(t)
+	if err != nil {
+		return "", err
+	}
+
+	var statement strings.Builder
+
+	statement.WriteString("CREATE TABLE ")
+
+	if opts.IfNotExists {
+		statement.WriteString("IF NOT EXISTS ")
+	}
+
+	statement.WriteString(table + " (\n")
+
+	for i, c := range l.columns {
+		definition, err := columnDefinition(t, c, valueTypes, opts.Dialect)
+		if err != nil {
+			return "", err
+		}
+
+		statement.WriteString("    " + definition)
+
+		if i < len(l.columns)-1 {
+			statement.WriteString(",")
+		}
+
+		statement.WriteString("\n")
+	}
+
+	statement.WriteString(");")
+
+	return statement.String(), nil
+}
+
+// nullableValueTypes returns the types of the values of the nullable fields of the struct type t, by field name.
+func nullableValueTypes(t reflect.Type) (map[string][]nullable.Field, error) {
+	fields, err := nullable.Fields(reflect.New(t).Interface())
+	if err != nil {
+		return nil, fmt.Errorf("nullsql: %w", err)
+	}
+
+	byName := map[string][]nullable.Field{}
+	for _, f := range fields {
+		byName[f.Name] = append(byName[f.Name], f)
+	}
+
+	return byName, nil
+}
+
+// columnDefinition returns the definition of the column c of the struct type t in the dialect.
+func columnDefinition(t reflect.Type, c column, valueTypes map[string][]nullable.Field, dialect Dialect) (string, error) {
+	f := fieldOf(t, c, valueTypes)
+
+	ddl, err := parseDDLTag(f.field.Tag.Get("ddl"))
+	if err != nil {
+		return "", fmt.Errorf("nullsql: ddl tag of the field %s of %s : %w", f.field.Name, t, err)
+	}
+
+	sqlType := ddl.sqlType
+
+	switch {
+	case dialect == Postgres && ddl.postgresType != "":
+		sqlType = ddl.postgresType
+	case dialect == SQLite && ddl.sqliteType != "":
+		sqlType = ddl.sqliteType
+	}
+
+	var check string
+
+	if sqlType == "" {
+		sqlType, check, err = columnType(c.name, f.valueType, f.nullable, dialect)
+		if err != nil {
+			return "", fmt.Errorf("nullsql: column %s of %s : %w", c.name, t, err)
+		}
+	}
+
+	definition := c.name + " " + sqlType
+	if !f.null {
+		definition += " NOT NULL"
+	}
+
+	if ddl.defaultValue != "" {
+		definition += " DEFAULT " + ddl.defaultValue
+	}
+
+	if check != "" {
+		definition += " " + check
+	}
+
+	if ddl.constraints != "" {
+		definition += " " + ddl.constraints
+	}
+
+	return definition, nil
+}
+
+// columnField is the field of a column.
+type columnField struct {
+	field reflect.StructField
+	// valueType is the type of the values of the field, T for nullable.Of[T] and *T.
+	valueType reflect.Type
+	// nullable is true iff the field is nullable, such as nullable.Of[T].
+	nullable bool
+	// null is true iff the values of the field can be null: the nullable fields, the pointers,
+	// and the fields of the embedded pointers.
+	null bool
+}
+
+// fieldOf returns the field of the column c of the struct type t, whose nullable fields are valueTypes.
+func fieldOf(t reflect.Type, c column, valueTypes map[string][]nullable.Field) columnField {
+	f := columnField{field: t.FieldByIndex(c.index)}
+	f.valueType = f.field.Type
+
+	for _, nf := range valueTypes[f.field.Name] {
+		if slices.Equal(nf.Index, c.index) {
+			f.valueType, f.nullable = nf.ValueType, true
+		}
+	}
+
+	switch {
+	case f.nullable:
+		f.null = true
+	case f.field.Type.Kind() == reflect.Pointer:
+		f.valueType, f.null = f.field.Type.Elem(), true
+	default:
+		f.null = embeddedPointer(t, c.index)
+	}
+
+	return f
+}
+
+// embeddedPointer returns true iff the field of the struct type t at index is the field of an embedded pointer.
+func embeddedPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		sf := t.Field(i)
+
+		t = sf.Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// columnType returns the SQL type of the values of type t of the column name, and its CHECK constraint if any.
+// The values of nullable types which have no SQL type are stored as JSON.
+func columnType(name string, t reflect.Type, nullableValue bool, dialect Dialect) (string, string, error) {
+	if types, ok := sqlTypes[t]; ok {
+		return types[dialect], "", nil
+	}
+
+	enumType := reflect.TypeFor[nullable.Enum]()
+	if t.Kind() == reflect.String && (t.Implements(enumType) || reflect.PointerTo(t).Implements(enumType)) {
+		values := reflect.New(t).Interface().(nullable.Enum).Values()
+
+		quoted := make([]string, len(values))
+		for i, value := range values {
+			quoted[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+		}
+
+		return "TEXT", "CHECK (" + name + " IN (" + strings.Join(quoted, ", ") + "))", nil
+	}
+
+	if reflect.PointerTo(t).Implements(reflect.TypeFor[driver.Valuer]()) {
+		// The SQL type of the values of a custom Valuer is unknown
+		return "", "", fmt.Errorf("%s : %w", t, ErrNoSQLType)
+	}
+
+	if basic, ok := basicTypes[t.Kind()]; ok {
+		// Named types whose underlying type is supported, such as type UserID int64
+		return sqlTypes[basic][dialect], "", nil
+	}
+
+	if nullableValue {
+		if dialect == SQLite {
+			return "TEXT", "", nil
+		}
+
+		return "JSONB", "", nil
+	}
+
+	return "", "", fmt.Errorf("%s : %w", t, ErrNoSQLType)
+}
+
+// parseDDLTag parses the ddl tag.
+func parseDDLTag(tag string) (columnDDL, error) {
+	var ddl columnDDL
+
+	if tag == "" {
+		return ddl, nil
+	}
+
+	for option := range strings.SplitSeq(tag, ";") {
+		key, value, _ := strings.Cut(option, ":")
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if value == "" {
+			return ddl, fmt.Errorf("missing value of %q", key)
+		}
+
+		switch key {
+		case "type":
+			ddl.sqlType = value
+		case "postgres_type":
+			ddl.postgresType = value
+		case "sqlite_type":
+			ddl.sqliteType = value
+		case "default":
+			ddl.defaultValue = value
+		case "constraints":
+			ddl.constraints = value
+		default:
+			return ddl, fmt.Errorf("unknown key %q", key)
+		}
+	}
+
+	return ddl, nil
+}
@@ -0,0 +1,229 @@
+package nullsql
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+)
+
+// MismatchKind is the kind of a mismatch between the fields of a struct and the columns of a result.
+type MismatchKind int
+
+const (
+	// NullableColumn is a nullable column whose field can't be null: its Scan fails on the first NULL.
+	NullableColumn MismatchKind = iota
+	// IncompatibleType is a column whose database type doesn't match the Go type of the values of its field.
+	IncompatibleType
+	// MissingColumn is a column of the struct which isn't a column of the result.
+	MissingColumn
+	// ExtraColumn is a column of the result which isn't a column of the struct.
+	ExtraColumn
+)
+
+// CheckOptions are the options of CheckColumns.
+type CheckOptions struct {
+	// IgnoreNullability doesn't check the nullability of the columns, for the drivers which report it wrongly,
+	// such as the SQLite driver modernc.org/sqlite which reports every column as nullable.
+	IgnoreNullability bool
+}
+
+// Mismatch is a mismatch between a field of a struct and a column of a result.
+type Mismatch struct {
+	Kind   MismatchKind
+	Column string
+	// Field is the Go name of the field, empty for ExtraColumn.
+	Field string
+	// GoType is the type of the field, nil for ExtraColumn.
+	GoType reflect.Type
+	// DatabaseType is the database type name of the column reported by the driver, empty for MissingColumn.
+	DatabaseType string
+}
+
+// String returns the description of the mismatch.
+func (m Mismatch) String() string {
+	switch m.Kind {
+	case NullableColumn:
+		return fmt.Sprintf("column %s is nullable but the field %s of type %s can't be null", m.Column, m.Field, m.GoType)
+	case IncompatibleType:
+		return fmt.Sprintf("column %s of type %s is incompatible with the field %s of type %s",
+			m.Column, m.DatabaseType, m.Field, m.GoType)
+	case MissingColumn:
+		return fmt.Sprintf("column %s of the field %s is not a column of the result", m.Column, m.Field)
+	case ExtraColumn:
+		return fmt.Sprintf("column %s is not a column of the struct", m.Column)
+	}
+
+	return fmt.Sprintf("mismatch %d of the column %s", m.Kind, m.Column)
+}
+
+// typeNames are the database type names reported by the drivers, in upper case, by type family.
+var typeNames = map[string][]string{
+	"bool":      {"BOOL", "BOOLEAN"},
+	"int2":      {"INT2", "SMALLINT", "SMALLSERIAL"},
+	"int4":      {"INT4", "INT", "INTEGER", "SERIAL"},
+	"int8":      {"INT8", "BIGINT", "BIGSERIAL"},
+	"float":     {"FLOAT4", "REAL", "FLOAT8", "FLOAT", "DOUBLE", "DOUBLE PRECISION"},
+	"numeric":   {"NUMERIC", "DECIMAL"},
+	"text":      {"TEXT", "VARCHAR", "CHAR", "BPCHAR", "CHARACTER", "CHARACTER VARYING", "NAME", "CITEXT"},
+	"bytes":     {"BYTEA", "BLOB"},
+	"uuid":      {"UUID"},
+	"json":      {"JSON", "JSONB"},
+	"timestamp": {"TIMESTAMP", "TIMESTAMPTZ", "DATETIME", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE"},
+	"date":      {"DATE"},
+	"time":      {"TIME", "TIMETZ", "TIME WITH TIME ZONE", "TIME WITHOUT TIME ZONE"},
+	"interval":  {"INTERVAL"},
+	"inet":      {"INET"},
+	"cidr":      {"CIDR"},
+	"macaddr":   {"MACADDR", "MACADDR8"},
+}
+
+// typeFamilies are the type families by database type name.
+var typeFamilies = familiesByName(typeNames)
+
+// compatibleFamilies are the type families of the columns compatible with the Go types of the values: the columns
+// whose values are scanned without loss of range. The integer types accept the narrower integer columns,
+// uint8 accepting all of them as its scan checks the range of the values as sql.NullByte does,
+// float64 accepts the integer and numeric columns, but the integer types don't accept the numeric columns,
+// whose values can have a fractional part, nor the float columns.
+var compatibleFamilies = map[reflect.Type][]string{
+	reflect.TypeFor[bool]():                    {"bool"},
+	reflect.TypeFor[int]():                     {"int2", "int4", "int8"},
+	reflect.TypeFor[int16]():                   {"int2"},
+	reflect.TypeFor[int32]():                   {"int2", "int4"},
+	reflect.TypeFor[int64]():                   {"int2", "int4", "int8"},
+	reflect.TypeFor[uint8]():                   {"int2", "int4", "int8"},
+	reflect.TypeFor[float64]():                 {"float", "numeric", "int2", "int4", "int8"},
+	reflect.TypeFor[string]():                  {"text", "uuid", "json", "numeric", "inet", "cidr", "macaddr", "interval"},
+	reflect.TypeFor[[]byte]():                  {"bytes", "text", "json"},
+	reflect.TypeFor[uuid.UUID]():               {"uuid", "text", "bytes"},
+	reflect.TypeFor[time.Time]():               {"timestamp", "date"},
+	reflect.TypeFor[time.Duration]():           {"interval", "text", "int8"},
+	reflect.TypeFor[nullable.ISODuration]():    {"interval", "text", "int8"},
+	reflect.TypeFor[nullable.StringDuration](): {"interval", "text", "int8"},
+	reflect.TypeFor[nullable.Date]():           {"date", "text"},
+	reflect.TypeFor[nullable.TimeOfDay]():      {"time", "text"},
+	reflect.TypeFor[netip.Addr]():              {"inet", "text"},
+	reflect.TypeFor[netip.Prefix]():            {"inet", "cidr", "text"},
+	reflect.TypeFor[net.HardwareAddr]():        {"macaddr", "text"},
+}
+
+// CheckColumns compares the columns of the struct type of v, a struct or a pointer to a struct, possibly nil,
+// with the columns of a result returned by sql.Rows.ColumnTypes, and returns their mismatches:
+// the nullable columns of fields which can't be null, the database types incompatible with the Go types
+// of the values of the fields, the columns of the struct missing from the result and the extra columns.
+//
+// The nullability of the columns is checked only if the driver reports it for every column, which pgx doesn't,
+// and if it isn't ignored by the options: the returned boolean is false otherwise. The database types which
+// are unknown, such as the PostgreSQL enum types and the types of the expressions in SQLite,
+// and the custom Go types, are not checked.
+func CheckColumns(v any, columns []*sql.ColumnType, opts CheckOptions) ([]Mismatch, bool, error) {
+	t, l, err := structType(v)
+	if err != nil {
+		return nil, false, err
+	}
+
+	valueTypes, err := nullableValueTypes(t)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var mismatches []Mismatch
+
+	nullabilityChecked := !opts.IgnoreNullability && !slices.ContainsFunc(columns, func(ct *sql.ColumnType) bool {
+		_, ok := ct.Nullable()
+
+		return !ok
+	})
+
+	for _, ct := range columns {
+		i := slices.IndexFunc(l.columns, func(c column) bool { return c.name == ct.Name() })
+		if i < 0 {
+			mismatches = append(mismatches, Mismatch{
+				Kind: ExtraColumn, Column: ct.Name(), DatabaseType: ct.DatabaseTypeName(),
+			})
+
+			continue
+		}
+
+		f := fieldOf(t, l.columns[i], valueTypes)
+		m := Mismatch{Column: ct.Name(), Field: f.field.Name, GoType: f.field.Type, DatabaseType: ct.DatabaseTypeName()}
+
+		if null, _ := ct.Nullable(); nullabilityChecked && null && !f.null {
+			m.Kind = NullableColumn
+			mismatches = append(mismatches, m)
+		}
+
+		if !compatibleType(f, ct.DatabaseTypeName()) {
+			m.Kind = IncompatibleType
+			mismatches = append(mismatches, m)
+		}
+	}
+
+	for _, c := range l.columns {
+		if !slices.ContainsFunc(columns, func(ct *sql.ColumnType) bool { return ct.Name() == c.name }) {
+			f := fieldOf(t, c, valueTypes)
+			mismatches = append(mismatches, Mismatch{
+				Kind: MissingColumn, Column: c.name, Field: f.field.Name, GoType: f.field.Type,
+			})
+		}
+	}
+
+	return mismatches, nullabilityChecked, nil
+}
+
+// compatibleType returns false iff the database type name is known and incompatible with the values of the field f.
+func compatibleType(f columnField, databaseType string) bool {
+	family, ok := typeFamily(databaseType)
+	if !ok {
+		return true
+	}
+
+	families, ok := compatibleFamilies[f.valueType]
+
+	switch {
+	case ok:
+	case f.valueType.Implements(reflect.TypeFor[sql.Scanner]()) ||
+		reflect.PointerTo(f.valueType).Implements(reflect.TypeFor[sql.Scanner]()):
+		// Custom types
+		return true
+	case basicTypes[f.valueType.Kind()] != nil:
+		// Named types whose underlying type is supported, such as type UserID int64, and the enums
+		families = compatibleFamilies[basicTypes[f.valueType.Kind()]]
+	case f.nullable:
+		// Values stored as JSON
+		families = []string{"json", "text", "bytes"}
+	default:
+		return true
+	}
+
+	return slices.Contains(families, family)
+}
+
+// typeFamily returns the family of the database type name, without its modifiers such as VARCHAR(255).
+func typeFamily(databaseType string) (string, bool) {
+	name, _, _ := strings.Cut(strings.ToUpper(databaseType), "(")
+	family, ok := typeFamilies[strings.TrimSpace(name)]
+
+	return family, ok
+}
+
+// familiesByName returns the type families by database type name of the database type names by family.
+func familiesByName(names map[string][]string) map[string]string {
+	families := map[string]string{}
+
+	for family, names := range names {
+		for _, name := range names {
+			families[name] = family
+		}
+	}
+
+	return families
+}
@@ -0,0 +1,238 @@
+package nullsql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PostgresMaxParams is the maximum number of parameters of a PostgreSQL statement.
+const PostgresMaxParams = 65535
+
+// InsertOptions are the options of Insert.
+type InsertOptions struct {
+	// Placeholder is the style of the placeholders, Dollar by default.
+	// The named placeholders are suffixed by the number of the row in the statement, such as :name_1.
+	Placeholder Placeholder
+	// Omit are the columns not inserted, such as the generated keys.
+	Omit []string
+	// Default are the columns inserted as DEFAULT when their value is null, besides the ones whose db tag
+	// has the option default. The other null values are inserted as NULL.
+	// SQLite doesn't support DEFAULT in VALUES.
+	Default []string
+	// Returning are the columns returned by the statements.
+	Returning []string
+	// MaxParams is the maximum number of parameters of a statement, PostgresMaxParams by default.
+	MaxParams int
+}
+
+// Statement is an SQL statement with its arguments.
+type Statement struct {
+	Query string
+	Args  []any
+}
+
+// ErrTooManyParams is returned when a row has more parameters than the maximum number of parameters of a statement.
+var ErrTooManyParams = errors.New("too many parameters")
+
+// Insert returns the INSERT statements of the rows, a slice or an array of structs or pointers to structs,
+// into the table. The rows are inserted by multi-row VALUES, split into several statements
+// so that each one has at most MaxParams parameters. It returns no statement if there are no rows.
+//
+// The method Value of each field implementing driver.Valuer is called once, its result being the argument,
+// so that the null values can be inserted as DEFAULT.
+func Insert(table string, rows any, opts InsertOptions) ([]Statement, error) {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("nullsql: the rows %T are not a slice", rows)
+	}
+
+	elem := rv.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullsql: the rows %T are not structs", rows)
+	}
+
+	l := layoutOf(elem)
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	columns, err := insertedColumns(l.columns, elem, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	maxParams := opts.MaxParams
+	if maxParams <= 0 {
+		maxParams = PostgresMaxParams
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+
+	head := "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ") VALUES "
+
+	var tail string
+	if len(opts.Returning) > 0 {
+		tail = " RETURNING " + strings.Join(opts.Returning, ", ")
+	}
+
+	var (
+		statements []Statement
+		query      strings.Builder
+		a          = args{placeholder: opts.Placeholder}
+		rowCount   int
+	)
+
+	flush := func() {
+		statements = append(statements, Statement{Query: query.String() + tail, Args: a.values})
+		query.Reset()
+		a = args{placeholder: opts.Placeholder}
+		rowCount = 0
+	}
+
+	for i := range rv.Len() {
+		values, err := rowValues(rv.Index(i), columns)
+		if err != nil {
+			return nil, fmt.Errorf("nullsql: row %d of %s : %w", i, table, err)
+		}
+
+		params := 0
+
+		for j, v := range values {
+			if v != nil || !columns[j].useDefault {
+				params++
+			}
+		}
+
+		if params > maxParams {
+			return nil, fmt.Errorf("nullsql: row %d of %s has %d parameters : %w", i, table, params, ErrTooManyParams)
+		}
+
+		if rowCount > 0 && len(a.values)+params > maxParams {
+			flush()
+		}
+
+		if rowCount == 0 {
+			query.WriteString(head)
+		} else {
+			query.WriteString(", ")
+		}
+
+		rowCount++
+
+		query.WriteString("(")
+
+		for j, v := range values {
+			if j > 0 {
+				query.WriteString(", ")
+			}
+
+			if v == nil && columns[j].useDefault {
+				query.WriteString("DEFAULT")
+
+				continue
+			}
+
+			query.WriteString(a.add(columns[j].name+"_"+strconv.Itoa(rowCount), v))
+		}
+
+		query.WriteString(")")
+	}
+
+	if rowCount > 0 {
+		flush()
+	}
+
+	return statements, nil
+}
+
+// insertedColumns returns the columns inserted according to opts, those of the struct type t.
+func insertedColumns(all []column, t reflect.Type, opts InsertOptions) ([]column, error) {
+	for _, name := range slices.Concat(opts.Omit, opts.Default) {
+		if !slices.ContainsFunc(all, func(c column) bool { return c.name == name }) {
+			return nil, fmt.Errorf("nullsql: column %q of %s : %w", name, t, ErrUnknownColumn)
+		}
+	}
+
+	columns := make([]column, 0, len(all))
+
+	for _, c := range all {
+		if slices.Contains(opts.Omit, c.name) {
+			continue
+		}
+
+		if slices.Contains(opts.Default, c.name) {
+			c.useDefault = true
+		}
+
+		columns = append(columns, c)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("nullsql: insert of %s : %w", t, ErrNoColumns)
+	}
+
+	return columns, nil
+}
+
+// rowValues returns the values of the columns of the row, a struct or a pointer to a struct,
+// nil for the null values.
+func rowValues(row reflect.Value, columns []column) ([]any, error) {
+	row, ok := addressableStruct(row)
+	if !ok {
+		return nil, errors.New("nil row")
+	}
+
+	values := make([]any, len(columns))
+
+	for i, c := range columns {
+		fv, err := row.FieldByIndexErr(c.index)
+		if err != nil {
+			// Field of a nil embedded pointer
+			continue
+		}
+
+		values[i], err = driverValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("value of the column %s : %w", c.name, err)
+		}
+	}
+
+	return values, nil
+}
+
+// driverValue returns the result of the method Value of the addressable field fv if it implements driver.Valuer,
+// or the value of the field, nil if it is a nil pointer.
+func driverValue(fv reflect.Value) (any, error) {
+	if v, ok := fv.Interface().(driver.Valuer); ok {
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			return nil, nil
+		}
+
+		return v.Value()
+	}
+
+	if v, ok := fv.Addr().Interface().(driver.Valuer); ok {
+		return v.Value()
+	}
+
+	switch fv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if fv.IsNil() {
+			return nil, nil
+		}
+	}
+
+	return fv.Interface(), nil
+}
@@ -0,0 +1,232 @@
+/*
+Package nullsql builds SQL statements from structs of nullable fields. The columns are the exported fields
+with a db tag, the tags used by sqlx, and the fields of the embedded structs without a db tag:
+
+	type UserPatch struct {
+		ID    int64               `db:"id"`
+		Name  nullable.Of[string] `db:"name"`
+		Email nullable.Of[string] `db:"email"`
+	}
+
+	query, args, err := nullsql.Update("users", patch, "id", nullsql.UpdateOptions{Include: nullsql.IncludeNonNull})
+	// UPDATE users SET name = $1 WHERE id = $2
+
+The db tag option default marks the columns inserted as DEFAULT when their value is null:
+
+	CreatedAt nullable.Of[time.Time] `db:"created_at,default"`
+
+The statements only use the types of database/sql, so that they can be run by any driver:
+the arguments are the values of the fields, nullable.Of[T] being a driver.Valuer, and sql.NamedArg
+values for the :name placeholders, which not all drivers support (see Named). The table and column names
+are written as is, without quoting.
+*/
+package nullsql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ovya/nullable"
+)
+
+// Placeholder is the style of the placeholders of the arguments of a statement.
+type Placeholder int
+
+const (
+	// Dollar placeholders are numbered: $1, $2, as in PostgreSQL.
+	Dollar Placeholder = iota
+	// Question placeholders are ?, as in MySQL and SQLite.
+	Question
+	// Named placeholders are :name, name being the column, the arguments being sql.NamedArg values.
+	// They are supported by the SQLite drivers modernc.org/sqlite and github.com/mattn/go-sqlite3,
+	// but not with PostgreSQL: pgx and github.com/lib/pq ignore the names of the arguments,
+	// and PostgreSQL rejects the :name placeholders.
+	Named
+)
+
+// Include selects the columns written by a statement.
+type Include int
+
+const (
+	// IncludeAll writes all the columns, the null values as NULL.
+	IncludeAll Include = iota
+	// IncludeNonNull writes the columns whose values are not null.
+	IncludeNonNull
+	// IncludeChanged writes the columns listed as changed.
+	IncludeChanged
+)
+
+var (
+	// ErrNoColumns is returned when a statement would write no column.
+	ErrNoColumns = errors.New("no column to write")
+	// ErrUnknownColumn is returned when a column isn't a column of the struct.
+	ErrUnknownColumn = errors.New("unknown column")
+)
+
+// column is a column of a struct type.
+type column struct {
+	name  string
+	index []int
+	// useDefault is true iff the db tag has the option default: a null value is inserted as DEFAULT.
+	useDefault bool
+}
+
+// layout is the columns of a struct type, or the error making it invalid.
+type layout struct {
+	columns []column
+	err     error
+}
+
+// layouts is the cache of the layouts by struct type.
+var layouts sync.Map // map[reflect.Type]layout
+
+// structValue returns the struct v, or the struct pointed to by v, addressable, with its columns.
+func structValue(v any) (reflect.Value, []column, error) {
+	rv, ok := addressableStruct(reflect.ValueOf(v))
+	if !ok {
+		return reflect.Value{}, nil, fmt.Errorf("nullsql: %T is not a struct or a pointer to a struct", v)
+	}
+
+	l := layoutOf(rv.Type())
+
+	return rv, l.columns, l.err
+}
+
+// addressableStruct returns the struct rv, or the struct pointed to by rv, addressable:
+// the methods IsNull and Value of the fields have pointer receivers.
+// It returns false if rv is neither a struct nor a non-nil pointer to a struct.
+func addressableStruct(rv reflect.Value) (reflect.Value, bool) {
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	if !rv.CanAddr() {
+		addressable := reflect.New(rv.Type()).Elem()
+		addressable.Set(rv)
+		rv = addressable
+	}
+
+	return rv, true
+}
+
+// structType returns the struct type of v, a struct or a pointer to a struct, possibly nil, with its layout.
+func structType(v any) (reflect.Type, layout, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, layout{}, fmt.Errorf("nullsql: %T is not a struct or a pointer to a struct", v)
+	}
+
+	l := layoutOf(t)
+
+	return t, l, l.err
+}
+
+// layoutOf returns the layout of the struct type t.
+func layoutOf(t reflect.Type) layout {
+	if cached, ok := layouts.Load(t); ok {
+		return cached.(layout)
+	}
+
+	var l layout
+
+	nullable.WalkFields(t, func(sf reflect.StructField) bool {
+		name, options, _ := strings.Cut(sf.Tag.Get("db"), ",")
+
+		switch {
+		case l.err != nil || name == "-":
+			return false
+		case name == "":
+			return true
+		case !sf.IsExported():
+			l.err = fmt.Errorf("nullsql: the field %s of %s with a db tag is not exported", sf.Name, t)
+
+			return false
+		}
+
+		l.columns = append(l.columns, column{
+			name:       name,
+			index:      sf.Index,
+			useDefault: slices.Contains(strings.Split(options, ","), "default"),
+		})
+
+		return false
+	})
+
+	if l.err == nil {
+		seen := map[string]bool{}
+
+		for _, c := range l.columns {
+			if seen[c.name] {
+				l.err = fmt.Errorf("nullsql: duplicate column %q in %s", c.name, t)
+
+				break
+			}
+
+			seen[c.name] = true
+		}
+	}
+
+	cached, _ := layouts.LoadOrStore(t, l)
+
+	return cached.(layout)
+}
+
+// fieldValue returns the value of the column c of the struct rv, and whether it is null:
+// the nullable values which are null, the nil pointers and the fields of the nil embedded pointers are null.
+func fieldValue(rv reflect.Value, c column) (any, bool) {
+	fv, err := rv.FieldByIndexErr(c.index)
+	if err != nil {
+		return nil, true
+	}
+
+	switch fv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if fv.IsNil() {
+			return nil, true
+		}
+	}
+
+	if n, ok := fv.Addr().Interface().(interface{ IsNull() bool }); ok {
+		return fv.Interface(), n.IsNull()
+	}
+
+	return fv.Interface(), false
+}
+
+// args accumulates the arguments of a statement.
+type args struct {
+	placeholder Placeholder
+	values      []any
+}
+
+// add adds the value of the column name and returns its placeholder.
+func (a *args) add(name string, value any) string {
+	switch a.placeholder {
+	case Question:
+		a.values = append(a.values, value)
+
+		return "?"
+	case Named:
+		a.values = append(a.values, sql.Named(name, value))
+
+		return ":" + name
+	default:
+		a.values = append(a.values, value)
+
+		return "$" + strconv.Itoa(len(a.values))
+	}
+}
@@ -0,0 +1,86 @@
+package nullsql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UpdateOptions are the options of Update.
+type UpdateOptions struct {
+	// Placeholder is the style of the placeholders, Dollar by default.
+	Placeholder Placeholder
+	// Include selects the columns set, all of them by default.
+	Include Include
+	// Changed are the columns set with IncludeChanged.
+	Changed []string
+}
+
+// Update returns the UPDATE statement of the table setting the columns of the struct v, or pointed to by v,
+// for the row whose column key has the value of v, and its arguments.
+// The key column is not set. It returns ErrNoColumns if no column is to be set.
+func Update(table string, v any, key string, opts UpdateOptions) (string, []any, error) {
+	rv, columns, err := structValue(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	keyIndex := slices.IndexFunc(columns, func(c column) bool { return c.name == key })
+	if keyIndex < 0 {
+		return "", nil, fmt.Errorf("nullsql: key %q of %s : %w", key, rv.Type(), ErrUnknownColumn)
+	}
+
+	keyValue, null := fieldValue(rv, columns[keyIndex])
+	if null {
+		return "", nil, fmt.Errorf("nullsql: the key %q of %s is null", key, rv.Type())
+	}
+
+	for _, name := range opts.Changed {
+		if !slices.ContainsFunc(columns, func(c column) bool { return c.name == name }) {
+			return "", nil, fmt.Errorf("nullsql: changed column %q of %s : %w", name, rv.Type(), ErrUnknownColumn)
+		}
+	}
+
+	a := args{placeholder: opts.Placeholder}
+
+	var query strings.Builder
+
+	query.WriteString("UPDATE " + table + " SET ")
+
+	set := 0
+
+	for i, c := range columns {
+		if i == keyIndex {
+			continue
+		}
+
+		value, null := fieldValue(rv, c)
+
+		switch opts.Include {
+		case IncludeNonNull:
+			if null {
+				continue
+			}
+		case IncludeChanged:
+			if !slices.Contains(opts.Changed, c.name) {
+				continue
+			}
+		}
+
+		if set > 0 {
+			query.WriteString(", ")
+		}
+
+		query.WriteString(c.name + " = " + a.add(c.name, value))
+
+		set++
+	}
+
+	if set == 0 {
+		return "", nil, fmt.Errorf("nullsql: update of %s : %w", table, ErrNoColumns)
+	}
+
+	query.WriteString(" WHERE " + key + " = " + a.add(key, keyValue))
+
+	return query.String(), a.values, nil
+}
@@ -0,0 +1,172 @@
+/*
+Package nullstruct converts between nullable.Of[T] and the structs made of a value field and a Valid bool field,
+such as the types of github.com/jackc/pgx/v5/pgtype, without depending on them:
+
+	text, err := nullstruct.To[pgtype.Text](name)         // nullable.Of[string] to pgtype.Text
+	name, err := nullstruct.From[string](text)            // pgtype.Text to nullable.Of[string]
+	id, err := nullstruct.From[uuid.UUID](pgUUID)         // pgtype.UUID{Bytes [16]byte} to nullable.Of[uuid.UUID]
+	at, err := nullstruct.From[time.Time](pgTimestamptz)  // pgtype.Timestamptz{Time, InfinityModifier} to nullable.Of[time.Time]
+
+The value field is the first field, other than Valid, whose type is T or has the same kind as T,
+all integer kinds being considered the same, so that pgtype.Int4 converts to nullable.Of[int64]
+and pgtype.UUID to nullable.Of[uuid.UUID]. The other fields are ignored by From and left zero by To,
+except an integer InfinityModifier field, as in pgtype.Timestamptz: From returns an error for the infinite values.
+*/
+package nullstruct
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ovya/nullable"
+)
+
+// layout is the index of the fields of a struct type holding a value of a given type.
+type layout struct {
+	value int
+	valid int
+	// infinity is the index of the InfinityModifier field, -1 if none.
+	infinity int
+}
+
+// layouts is the cache of the layouts by struct and value types.
+var layouts sync.Map // map[[2]reflect.Type]layout
+
+// From returns the nullable value of s, which must be a struct with a Valid bool field
+// and a value field for T. It returns null if Valid is false.
+// An error is returned if the value overflows T, or if it is infinite.
+func From[T, S any](s S) (nullable.Of[T], error) {
+	t := reflect.TypeFor[T]()
+
+	l, err := layoutOf(reflect.TypeFor[S](), t)
+	if err != nil {
+		return nullable.Null[T](), err
+	}
+
+	rs := reflect.ValueOf(s)
+	if !rs.Field(l.valid).Bool() {
+		return nullable.Null[T](), nil
+	}
+
+	if l.infinity >= 0 && !rs.Field(l.infinity).IsZero() {
+		return nullable.Null[T](), fmt.Errorf("nullstruct: infinite value of %s", rs.Type())
+	}
+
+	value := rs.Field(l.value)
+	if isInteger(value.Kind()) && overflows(t, value) {
+		return nullable.Null[T](), fmt.Errorf("nullstruct: value %v of %s.%s overflows %s",
+			value, rs.Type(), rs.Type().Field(l.value).Name, t)
+	}
+
+	return nullable.FromValue(value.Convert(t).Interface().(T)), nil
+}
+
+// To returns the struct S holding the value of n, with Valid set to false if n is null.
+// S must be a struct with a Valid bool field and a value field for T.
+// An error is returned if the value overflows the value field.
+func To[S, T any](n nullable.Of[T]) (S, error) {
+	var out S
+
+	l, err := layoutOf(reflect.TypeFor[S](), reflect.TypeFor[T]())
+	if err != nil {
+		return out, err
+	}
+
+	if n.IsNull() {
+		return out, nil
+	}
+
+	rs := reflect.ValueOf(&out).Elem()
+	field := rs.Field(l.value)
+	value := reflect.ValueOf(n.GetValue()).Elem()
+
+	if isInteger(value.Kind()) && overflows(field.Type(), value) {
+		return out, fmt.Errorf("nullstruct: value %v overflows %s.%s", value, rs.Type(), rs.Type().Field(l.value).Name)
+	}
+
+	field.Set(value.Convert(field.Type()))
+	rs.Field(l.valid).SetBool(true)
+
+	return out, nil
+}
+
+// layoutOf returns the layout of the struct type s for the values of type t.
+func layoutOf(s, t reflect.Type) (layout, error) {
+	key := [2]reflect.Type{s, t}
+	if l, ok := layouts.Load(key); ok {
+		return l.(layout), nil
+	}
+
+	if s.Kind() != reflect.Struct {
+		return layout{}, fmt.Errorf("nullstruct: %s is not a struct", s)
+	}
+
+	l := layout{value: -1, valid: -1, infinity: -1}
+
+	for i := range s.NumField() {
+		field := s.Field(i)
+
+		switch {
+		case !field.IsExported():
+		case field.Name == "Valid" && field.Type.Kind() == reflect.Bool:
+			l.valid = i
+		case field.Name == "InfinityModifier" && isInteger(field.Type.Kind()):
+			l.infinity = i
+		case l.value < 0 && holds(field.Type, t):
+			l.value = i
+		}
+	}
+
+	if l.valid < 0 {
+		return layout{}, fmt.Errorf("nullstruct: %s has no Valid bool field", s)
+	}
+
+	if l.value < 0 {
+		return layout{}, fmt.Errorf("nullstruct: %s has no field for %s", s, t)
+	}
+
+	layouts.Store(key, l)
+
+	return l, nil
+}
+
+// holds returns true iff a field of type field can hold a value of type t.
+func holds(field, t reflect.Type) bool {
+	switch {
+	case field == t:
+		return true
+	case !field.ConvertibleTo(t) || !t.ConvertibleTo(field):
+		return false
+	case isInteger(field.Kind()) && isInteger(t.Kind()):
+		return true
+	}
+
+	return field.Kind() == t.Kind()
+}
+
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+// overflows returns true iff the integer value can't be represented by the type t.
+func overflows(t reflect.Type, value reflect.Value) bool {
+	converted := value.Convert(t)
+
+	switch {
+	case !converted.Convert(value.Type()).Equal(value):
+		return true
+	case value.CanInt() && converted.CanUint():
+		return value.Int() < 0
+	case value.CanUint() && converted.CanInt():
+		return converted.Int() < 0
+	}
+
+	return false
+}
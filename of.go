@@ -1,9 +1,13 @@
 package nullable
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
 	"time"
 
 	"github.com/google/uuid"
@@ -69,6 +73,13 @@ func (n Of[T]) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
+	switch value := any(n.val).(type) {
+	case *net.HardwareAddr:
+		return json.Marshal(value.String())
+	case *time.Duration:
+		return marshalDurationJSON(*value)
+	}
+
 	return marshalJSON(&n)
 }
 
@@ -84,6 +95,15 @@ func (n *Of[T]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	switch any(n.val).(type) {
+	case *netip.Addr, *netip.Prefix, *net.HardwareAddr:
+		return n.unmarshalNetJSON(data)
+	case *time.Duration:
+		return n.unmarshalDurationJSON(data)
+	case Enum:
+		return n.unmarshalEnumJSON(data)
+	}
+
 	if n.val == nil {
 		n.val = new(T)
 	}
@@ -102,10 +122,40 @@ func (n Of[T]) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
+	if c, ok := lookupCodec[T](); ok && c.value != nil {
+		v, err := c.value(*n.val)
+		if err != nil {
+			return nil, fmt.Errorf("registered codec value error on nullable : %w", err)
+		}
+
+		return v, nil
+	}
+
 	switch value := any(n.val).(type) {
-	case *string, *int16, *int32, *int, *int64, *float64, *bool, *time.Time, *uuid.UUID, string,
+	case *string, *int64, *float64, *bool, *time.Time, string,
 		int16, int32, int, int64, float64, bool, time.Time, uuid.UUID:
 		return *n.val, nil
+	// The other integers and the UUIDs are returned as int64 and text, which are driver.Value types:
+	// database/sql rejects the other types returned by Value unless the driver accepts them, as pgx does,
+	// so that they couldn't be written with SQLite. PostgreSQL converts them to smallint, integer and uuid.
+	case *uuid.UUID:
+		return value.String(), nil
+	case *int16:
+		return int64(*value), nil
+	case *int32:
+		return int64(*value), nil
+	case *int:
+		return int64(*value), nil
+	case *uint8:
+		return int64(*value), nil
+	case *netip.Addr, *netip.Prefix, *net.HardwareAddr:
+		return netValue(value)
+	case *time.Duration:
+		return FormatInterval(*value), nil
+	case *Date, *TimeOfDay:
+		return calendarValue(value)
+	case Enum:
+		return enumValue(n.val)
 	case JSON:
 		if value == nil {
 			return nil, nil
@@ -120,6 +170,10 @@ func (n Of[T]) Value() (driver.Value, error) {
 			return v, nil
 		}
 
+		if kind := underlyingKind[T](); kind != reflect.Invalid {
+			return underlyingValue(kind, n.val)
+		}
+
 		b, err := json.Marshal(value)
 		if err != nil {
 			return nil, fmt.Errorf("nullable database value error : %w", err)
@@ -138,12 +192,22 @@ func (n *Of[T]) Scan(v any) error {
 		n = new(Of[T])
 	}
 
+	if c, ok := lookupCodec[T](); ok && c.scan != nil {
+		return n.scanCodec(c.scan, v)
+	}
+
 	switch any(n.val).(type) {
 	case *string:
 		return n.scanString(v)
 	case *uuid.UUID:
 		return n.scanUUID(v)
-	case *int16, *int32, *int, *int64:
+	case *netip.Addr:
+		return n.scanAddr(v)
+	case *netip.Prefix:
+		return n.scanPrefix(v)
+	case *net.HardwareAddr:
+		return n.scanHardwareAddr(v)
+	case *int16, *int32, *int, *int64, *uint8:
 		return n.scanInt(v)
 	case *float64:
 		return n.scanFloat(v)
@@ -151,7 +215,21 @@ func (n *Of[T]) Scan(v any) error {
 		return n.scanBool(v)
 	case *time.Time:
 		return n.scanTime(v)
+	case *time.Duration:
+		return n.scanDuration(v)
+	case *Date:
+		return n.scanDate(v)
+	case *TimeOfDay:
+		return n.scanTimeOfDay(v)
+	case Enum:
+		return n.scanEnum(v)
 	case *JSON, JSON:
+		if _, ok := any(n.val).(sql.Scanner); !ok {
+			if kind := underlyingKind[T](); kind != reflect.Invalid {
+				return n.scanUnderlying(kind, v)
+			}
+		}
+
 		return n.scanJSON(v)
 	}
 
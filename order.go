@@ -0,0 +1,72 @@
+package nullable
+
+import (
+	"bytes"
+	"cmp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b.
+// Nulls are equal to each other, and sorted before the values if nullsFirst is set, after otherwise.
+// PostgreSQL sorts nulls last in ascending order, as ORDER BY x ASC NULLS LAST:
+//
+//	slices.SortFunc(rows, func(a, b Row) int { return nullable.Compare(a.Score, b.Score, false) })
+//
+// For a descending order, swap a and b and invert nullsFirst: ORDER BY x DESC sorts nulls first in PostgreSQL.
+func Compare[T cmp.Ordered](a, b Of[T], nullsFirst bool) int {
+	return CompareFunc(a, b, nullsFirst, cmp.Compare[T])
+}
+
+// Less reports whether a is less than b, with the order of Compare.
+func Less[T cmp.Ordered](a, b Of[T], nullsFirst bool) bool {
+	return Compare(a, b, nullsFirst) < 0
+}
+
+// CompareFunc is like Compare but compares the values with the function compare,
+// which permits to order the types which are not cmp.Ordered.
+func CompareFunc[T any](a, b Of[T], nullsFirst bool, compare func(T, T) int) int {
+	switch {
+	case a.IsNull() && b.IsNull():
+		return 0
+	case a.IsNull():
+		if nullsFirst {
+			return -1
+		}
+
+		return 1
+	case b.IsNull():
+		if nullsFirst {
+			return 1
+		}
+
+		return -1
+	}
+
+	return compare(*a.val, *b.val)
+}
+
+// CompareUUID is Compare for uuid.UUID.
+// UUIDs are ordered byte per byte, as PostgreSQL does.
+func CompareUUID(a, b Of[uuid.UUID], nullsFirst bool) int {
+	return CompareFunc(a, b, nullsFirst, func(x, y uuid.UUID) int { return bytes.Compare(x[:], y[:]) })
+}
+
+// CompareTime is Compare for time.Time.
+// Times are ordered by instant, whatever their location.
+func CompareTime(a, b Of[time.Time], nullsFirst bool) int {
+	return CompareFunc(a, b, nullsFirst, time.Time.Compare)
+}
+
+// Equal reports whether a and b hold equal values.
+// Nulls are equal to each other if nullsEqual is set, as with the SQL IS NOT DISTINCT FROM.
+// Otherwise a null is equal to nothing, as with the SQL = in a WHERE clause.
+// A null is never equal to a value.
+func Equal[T comparable](a, b Of[T], nullsEqual bool) bool {
+	if a.IsNull() || b.IsNull() {
+		return nullsEqual && a.IsNull() && b.IsNull()
+	}
+
+	return *a.val == *b.val
+}
@@ -0,0 +1,47 @@
+package nullable
+
+import "iter"
+
+// All returns a sequence of zero or one element: the value if n is not null.
+//
+//	for v := range n.All() {
+//		// only run if n is not null
+//	}
+func (n Of[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if !n.IsNull() {
+			yield(*n.val)
+		}
+	}
+}
+
+// Values returns the sequence of the non-null values of seq, in order:
+//
+//	names := slices.Collect(nullable.Values(slices.Values(rows)))
+func Values[T any](seq iter.Seq[Of[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range seq {
+			if !n.IsNull() && !yield(*n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns the sequence of the non-null values of seq with their index in seq,
+// nulls are skipped but counted:
+//
+//	present := maps.Collect(nullable.Indexed(slices.Values(rows))) // map[int]T
+func Indexed[T any](seq iter.Seq[Of[T]]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+
+		for n := range seq {
+			if !n.IsNull() && !yield(i, *n.val) {
+				return
+			}
+
+			i++
+		}
+	}
+}
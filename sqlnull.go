@@ -0,0 +1,162 @@
+package nullable
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FromSQLNull returns the Of[T] holding the same value as n.
+func FromSQLNull[T any](n sql.Null[T]) Of[T] {
+	if !n.Valid {
+		return Null[T]()
+	}
+
+	return FromValue(n.V)
+}
+
+// ToSQLNull returns the sql.Null[T] holding the same value as n.
+func ToSQLNull[T any](n Of[T]) sql.Null[T] {
+	if n.IsNull() {
+		return sql.Null[T]{}
+	}
+
+	return sql.Null[T]{V: *n.val, Valid: true}
+}
+
+// FromPtr returns an Of[T] holding a copy of *p, or null if p is nil.
+// Unlike SetValueP, the returned value doesn't depend on the receiver.
+func FromPtr[T any](p *T) Of[T] {
+	if p == nil {
+		return Null[T]()
+	}
+
+	return FromValue(*p)
+}
+
+// ToPtr returns a pointer to a copy of the value of n, or nil if n is null.
+// Unlike GetValue, modifying the pointed value doesn't modify n.
+func ToPtr[T any](n Of[T]) *T {
+	if n.IsNull() {
+		return nil
+	}
+
+	v := *n.val
+
+	return &v
+}
+
+// FromNullString returns the Of[string] holding the same value as n.
+func FromNullString(n sql.NullString) Of[string] {
+	return fromValid(n.String, n.Valid)
+}
+
+// ToNullString returns the sql.NullString holding the same value as n.
+func ToNullString(n Of[string]) sql.NullString {
+	v, valid := toValid(n)
+
+	return sql.NullString{String: v, Valid: valid}
+}
+
+// FromNullInt64 returns the Of[int64] holding the same value as n.
+func FromNullInt64(n sql.NullInt64) Of[int64] {
+	return fromValid(n.Int64, n.Valid)
+}
+
+// ToNullInt64 returns the sql.NullInt64 holding the same value as n.
+func ToNullInt64(n Of[int64]) sql.NullInt64 {
+	v, valid := toValid(n)
+
+	return sql.NullInt64{Int64: v, Valid: valid}
+}
+
+// FromNullInt32 returns the Of[int32] holding the same value as n.
+func FromNullInt32(n sql.NullInt32) Of[int32] {
+	return fromValid(n.Int32, n.Valid)
+}
+
+// ToNullInt32 returns the sql.NullInt32 holding the same value as n.
+func ToNullInt32(n Of[int32]) sql.NullInt32 {
+	v, valid := toValid(n)
+
+	return sql.NullInt32{Int32: v, Valid: valid}
+}
+
+// FromNullInt16 returns the Of[int16] holding the same value as n.
+func FromNullInt16(n sql.NullInt16) Of[int16] {
+	return fromValid(n.Int16, n.Valid)
+}
+
+// ToNullInt16 returns the sql.NullInt16 holding the same value as n.
+func ToNullInt16(n Of[int16]) sql.NullInt16 {
+	v, valid := toValid(n)
+
+	return sql.NullInt16{Int16: v, Valid: valid}
+}
+
+// FromNullByte returns the Of[byte] holding the same value as n.
+func FromNullByte(n sql.NullByte) Of[byte] {
+	return fromValid(n.Byte, n.Valid)
+}
+
+// ToNullByte returns the sql.NullByte holding the same value as n.
+func ToNullByte(n Of[byte]) sql.NullByte {
+	v, valid := toValid(n)
+
+	return sql.NullByte{Byte: v, Valid: valid}
+}
+
+// FromNullFloat64 returns the Of[float64] holding the same value as n.
+func FromNullFloat64(n sql.NullFloat64) Of[float64] {
+	return fromValid(n.Float64, n.Valid)
+}
+
+// ToNullFloat64 returns the sql.NullFloat64 holding the same value as n.
+func ToNullFloat64(n Of[float64]) sql.NullFloat64 {
+	v, valid := toValid(n)
+
+	return sql.NullFloat64{Float64: v, Valid: valid}
+}
+
+// FromNullBool returns the Of[bool] holding the same value as n.
+func FromNullBool(n sql.NullBool) Of[bool] {
+	return fromValid(n.Bool, n.Valid)
+}
+
+// ToNullBool returns the sql.NullBool holding the same value as n.
+func ToNullBool(n Of[bool]) sql.NullBool {
+	v, valid := toValid(n)
+
+	return sql.NullBool{Bool: v, Valid: valid}
+}
+
+// FromNullTime returns the Of[time.Time] holding the same value as n.
+func FromNullTime(n sql.NullTime) Of[time.Time] {
+	return fromValid(n.Time, n.Valid)
+}
+
+// ToNullTime returns the sql.NullTime holding the same value as n.
+func ToNullTime(n Of[time.Time]) sql.NullTime {
+	v, valid := toValid(n)
+
+	return sql.NullTime{Time: v, Valid: valid}
+}
+
+// fromValid returns v, or null if valid is false.
+func fromValid[T any](v T, valid bool) Of[T] {
+	if !valid {
+		return Null[T]()
+	}
+
+	return FromValue(v)
+}
+
+// toValid returns the value of n and true, or the zero value and false if n is null.
+func toValid[T any](n Of[T]) (T, bool) {
+	if n.IsNull() {
+		var zero T
+
+		return zero, false
+	}
+
+	return *n.val, true
+}
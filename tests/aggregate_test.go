@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregates(t *testing.T) {
+	values := []nullable.Of[int]{
+		nullable.FromValue(4),
+		nullable.Null[int](),
+		nullable.FromValue(-2),
+		nullable.FromValue(7),
+		nullable.Null[int](),
+	}
+
+	t.Run("Slice", func(t *testing.T) {
+		assert.Equal(t, 3, nullable.Count(values))
+		assert.Equal(t, nullable.FromValue(9), nullable.Sum(values))
+		assert.Equal(t, nullable.FromValue(-2), nullable.Min(values))
+		assert.Equal(t, nullable.FromValue(7), nullable.Max(values))
+		assert.Equal(t, nullable.FromValue(3.0), nullable.Avg(values))
+		assert.Equal(t, []int{4, -2, 7}, nullable.Compact(values))
+	})
+
+	t.Run("Seq", func(t *testing.T) {
+		byID := map[string]nullable.Of[float64]{
+			"a": nullable.FromValue(1.5),
+			"b": nullable.Null[float64](),
+			"c": nullable.FromValue(2.5),
+		}
+
+		assert.Equal(t, 2, nullable.CountSeq(maps.Values(byID)))
+		assert.Equal(t, nullable.FromValue(4.0), nullable.SumSeq(maps.Values(byID)))
+		assert.Equal(t, nullable.FromValue(1.5), nullable.MinSeq(maps.Values(byID)))
+		assert.Equal(t, nullable.FromValue(2.5), nullable.MaxSeq(maps.Values(byID)))
+		assert.Equal(t, nullable.FromValue(2.0), nullable.AvgSeq(maps.Values(byID)))
+		assert.ElementsMatch(t, []float64{1.5, 2.5}, nullable.CompactSeq(maps.Values(byID)))
+	})
+
+	t.Run("Only nulls", func(t *testing.T) {
+		nulls := []nullable.Of[int]{nullable.Null[int](), nullable.Null[int]()}
+
+		assert.Equal(t, 0, nullable.Count(nulls))
+		assert.Equal(t, nullable.Null[int](), nullable.Sum(nulls), "SUM of nulls is NULL, not 0")
+		assert.Equal(t, nullable.Null[int](), nullable.Min(nulls))
+		assert.Equal(t, nullable.Null[int](), nullable.Max(nulls))
+		assert.Equal(t, nullable.Null[float64](), nullable.Avg(nulls))
+		assert.Empty(t, nullable.Compact(nulls))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, 0, nullable.Count[int](nil))
+		assert.Equal(t, nullable.Null[int](), nullable.Sum[int](nil))
+		assert.Equal(t, nullable.Null[float64](), nullable.Avg[int](nil))
+		assert.Equal(t, []int{}, nullable.Compact[int](nil))
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		names := []nullable.Of[string]{nullable.FromValue("bob"), nullable.Null[string](), nullable.FromValue("alice")}
+
+		assert.Equal(t, nullable.FromValue("alice"), nullable.Min(names))
+		assert.Equal(t, nullable.FromValue("bob"), nullable.Max(names))
+	})
+}
+
+// TestAggregatesSQLite checks the results against the SQL aggregate functions.
+func TestAggregatesSQLite(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	for _, values := range [][]nullable.Of[int64]{
+		{nullable.FromValue(int64(4)), nullable.Null[int64](), nullable.FromValue(int64(-2)), nullable.FromValue(int64(7))},
+		{nullable.Null[int64](), nullable.Null[int64]()},
+		{},
+	} {
+		_, err := db.Exec("DELETE FROM type_test")
+		require.NoError(t, err)
+
+		for _, v := range values {
+			_, err := db.Exec("INSERT INTO type_test (int64_val) VALUES (?)", &v)
+			require.NoError(t, err)
+		}
+
+		var count int
+		var sum, least, greatest nullable.Of[int64]
+		var avg nullable.Of[float64]
+
+		err = db.QueryRow("SELECT COUNT(int64_val), SUM(int64_val), MIN(int64_val), MAX(int64_val), AVG(int64_val) FROM type_test").
+			Scan(&count, &sum, &least, &greatest, &avg)
+		require.NoError(t, err)
+
+		assert.Equal(t, count, nullable.Count(values))
+		assert.Equal(t, sum, nullable.Sum(values))
+		assert.Equal(t, least, nullable.Min(values))
+		assert.Equal(t, greatest, nullable.Max(values))
+		assert.Equal(t, avg, nullable.Avg(values))
+		assert.Equal(t, nullable.Compact(values), nullable.CompactSeq(slices.Values(values)))
+	}
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type amount int64
+
+func TestArithmetic(t *testing.T) {
+	seven := nullable.FromValue(7)
+	minusTwo := nullable.FromValue(-2)
+	zero := nullable.FromValue(0)
+	null := nullable.Null[int]()
+
+	t.Run("Operators", func(t *testing.T) {
+		assert.Equal(t, nullable.FromValue(5), nullable.Add(seven, minusTwo))
+		assert.Equal(t, nullable.FromValue(9), nullable.Sub(seven, minusTwo))
+		assert.Equal(t, nullable.FromValue(-14), nullable.Mul(seven, minusTwo))
+		assert.Equal(t, nullable.FromValue(-7), nullable.Neg(seven))
+		assert.Equal(t, nullable.FromValue(2), nullable.Abs(minusTwo))
+		assert.Equal(t, seven, nullable.Abs(seven))
+
+		div, err := nullable.Div(seven, minusTwo)
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(-3), div, "integer division truncates toward zero")
+
+		fdiv, err := nullable.Div(nullable.FromValue(7.0), nullable.FromValue(2.0))
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(3.5), fdiv)
+
+		mod, err := nullable.Mod(nullable.FromValue(-7), nullable.FromValue(2))
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(-1), mod, "the sign of the modulo is the one of the dividend")
+	})
+
+	t.Run("Results don't alias the operands", func(t *testing.T) {
+		a := nullable.FromValue(7)
+		abs := nullable.Abs(a)
+		*a.GetValue() = -1
+
+		assert.Equal(t, 7, *abs.GetValue())
+	})
+
+	t.Run("Null propagation", func(t *testing.T) {
+		assert.Equal(t, null, nullable.Add(seven, null))
+		assert.Equal(t, null, nullable.Add(null, seven))
+		assert.Equal(t, null, nullable.Sub(null, null))
+		assert.Equal(t, null, nullable.Mul(null, zero))
+		assert.Equal(t, null, nullable.Neg(null))
+		assert.Equal(t, null, nullable.Abs(null))
+
+		div, err := nullable.Div(null, seven)
+		require.NoError(t, err)
+		assert.True(t, div.IsNull())
+
+		div, err = nullable.Div(seven, null)
+		require.NoError(t, err)
+		assert.True(t, div.IsNull())
+
+		mod, err := nullable.Mod(null, seven)
+		require.NoError(t, err)
+		assert.True(t, mod.IsNull())
+	})
+
+	t.Run("Division by zero", func(t *testing.T) {
+		_, err := nullable.Div(seven, zero)
+		assert.ErrorIs(t, err, nullable.ErrDivisionByZero)
+
+		_, err = nullable.Div(nullable.FromValue(1.5), nullable.FromValue(0.0))
+		assert.ErrorIs(t, err, nullable.ErrDivisionByZero)
+
+		div, err := nullable.Div(null, zero)
+		require.NoError(t, err, "NULL / 0 is NULL")
+		assert.True(t, div.IsNull())
+
+		_, err = nullable.Mod(seven, zero)
+		assert.ErrorIs(t, err, nullable.ErrDivisionByZero)
+
+		mod, err := nullable.Mod(null, zero)
+		require.NoError(t, err, "NULL % 0 is NULL")
+		assert.True(t, mod.IsNull())
+
+		assert.Equal(t, null, nullable.DivOrNull(seven, zero))
+		assert.Equal(t, null, nullable.ModOrNull(seven, zero))
+		assert.Equal(t, nullable.FromValue(3), nullable.DivOrNull(seven, nullable.FromValue(2)))
+		assert.Equal(t, nullable.FromValue(1), nullable.ModOrNull(seven, nullable.FromValue(2)))
+	})
+
+	t.Run("Named types", func(t *testing.T) {
+		total := nullable.Add(nullable.FromValue(amount(10)), nullable.FromValue(amount(32)))
+		assert.Equal(t, amount(42), *total.GetValue())
+	})
+
+	t.Run("Comparisons", func(t *testing.T) {
+		assert.Equal(t, sqlFalse, nullable.Eq(seven, minusTwo))
+		assert.Equal(t, sqlTrue, nullable.Eq(seven, seven))
+		assert.Equal(t, sqlTrue, nullable.Ne(seven, minusTwo))
+		assert.Equal(t, sqlFalse, nullable.Lt(seven, minusTwo))
+		assert.Equal(t, sqlTrue, nullable.Le(seven, seven))
+		assert.Equal(t, sqlTrue, nullable.Gt(seven, minusTwo))
+		assert.Equal(t, sqlFalse, nullable.Ge(minusTwo, seven))
+		assert.Equal(t, sqlTrue, nullable.Lt(nullable.FromValue("a"), nullable.FromValue("b")))
+
+		for _, cmp := range []func(a, b nullable.Of[int]) nullable.Of[bool]{
+			nullable.Eq[int], nullable.Ne[int], nullable.Lt[int], nullable.Le[int], nullable.Gt[int], nullable.Ge[int],
+		} {
+			assert.Equal(t, nullable.Null[bool](), cmp(seven, null))
+			assert.Equal(t, nullable.Null[bool](), cmp(null, seven))
+			assert.Equal(t, nullable.Null[bool](), cmp(null, null), "NULL = NULL is NULL")
+		}
+	})
+}
+
+// TestArithmeticSQLite checks the results against the SQL evaluation.
+// SQLite returns NULL on division by zero, as DivOrNull and ModOrNull do.
+func TestArithmeticSQLite(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	values := []nullable.Of[int64]{
+		nullable.FromValue(int64(7)),
+		nullable.FromValue(int64(-2)),
+		nullable.FromValue(int64(0)),
+		nullable.Null[int64](),
+	}
+
+	for _, a := range values {
+		for _, b := range values {
+			t.Run(valueName(a)+" "+valueName(b), func(t *testing.T) {
+				var add, sub, mul, div, mod, neg, abs nullable.Of[int64]
+				var eq, ne, lt, le, gt, ge nullable.Of[bool]
+
+				err := db.QueryRow(`SELECT ?1 + ?2, ?1 - ?2, ?1 * ?2, ?1 / ?2, ?1 % ?2, -?1, abs(?1),
+					?1 = ?2, ?1 <> ?2, ?1 < ?2, ?1 <= ?2, ?1 > ?2, ?1 >= ?2`, &a, &b).
+					Scan(&add, &sub, &mul, &div, &mod, &neg, &abs, &eq, &ne, &lt, &le, &gt, &ge)
+				require.NoError(t, err)
+
+				assert.Equal(t, add, nullable.Add(a, b), "+")
+				assert.Equal(t, sub, nullable.Sub(a, b), "-")
+				assert.Equal(t, mul, nullable.Mul(a, b), "*")
+				assert.Equal(t, div, nullable.DivOrNull(a, b), "/")
+				assert.Equal(t, mod, nullable.ModOrNull(a, b), "%")
+				assert.Equal(t, neg, nullable.Neg(a), "neg")
+				assert.Equal(t, abs, nullable.Abs(a), "abs")
+				assert.Equal(t, eq, nullable.Eq(a, b), "=")
+				assert.Equal(t, ne, nullable.Ne(a, b), "<>")
+				assert.Equal(t, lt, nullable.Lt(a, b), "<")
+				assert.Equal(t, le, nullable.Le(a, b), "<=")
+				assert.Equal(t, gt, nullable.Gt(a, b), ">")
+				assert.Equal(t, ge, nullable.Ge(a, b), ">=")
+			})
+		}
+	}
+}
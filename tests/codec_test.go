@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// celsius would be handled as float64 if it had no registered codec
+type celsius float64
+
+func init() {
+	nullable.RegisterCodec(
+		func(v any) (big.Rat, error) {
+			var r big.Rat
+
+			var s string
+			switch value := v.(type) {
+			case string:
+				s = value
+			case []byte:
+				s = string(value)
+			case int64:
+				s = strconv.FormatInt(value, 10)
+			default:
+				return r, fmt.Errorf("cannot scan %T as big.Rat", v)
+			}
+
+			if _, ok := r.SetString(s); !ok {
+				return r, fmt.Errorf("invalid rational %q", s)
+			}
+
+			return r, nil
+		},
+		func(r big.Rat) (driver.Value, error) {
+			return r.FloatString(10), nil
+		},
+	)
+
+	nullable.RegisterCodec(
+		func(v any) (celsius, error) {
+			s, ok := v.(string)
+			if !ok {
+				return 0, errors.New("expecting a string")
+			}
+
+			f, err := strconv.ParseFloat(strings.TrimSuffix(s, "°C"), 64)
+
+			return celsius(f), err
+		},
+		func(c celsius) (driver.Value, error) {
+			return strconv.FormatFloat(float64(c), 'f', -1, 64) + "°C", nil
+		},
+	)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		var n nullable.Of[big.Rat]
+		require.NoError(t, n.Scan([]byte("12.50")))
+		assert.Equal(t, "25/2", n.GetValue().RatString())
+	})
+
+	t.Run("scan null", func(t *testing.T) {
+		n := nullable.FromValue(*big.NewRat(1, 2))
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		var n nullable.Of[big.Rat]
+		assert.Error(t, n.Scan("not a number"))
+		assert.Error(t, n.Scan(true))
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := nullable.FromValue(*big.NewRat(25, 2)).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "12.5000000000", v)
+	})
+
+	t.Run("value null", func(t *testing.T) {
+		v, err := nullable.Null[big.Rat]().Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("codec takes precedence over built-in handling", func(t *testing.T) {
+		var n nullable.Of[celsius]
+		require.NoError(t, n.Scan("21.5°C"))
+		assert.Equal(t, celsius(21.5), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "21.5°C", v)
+
+		assert.Error(t, n.Scan(21.5))
+	})
+
+	t.Run("concurrent lookups", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := range 16 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				var n nullable.Of[big.Rat]
+				assert.NoError(t, n.Scan(int64(i)))
+				_, err := n.Value()
+				assert.NoError(t, err)
+			}()
+		}
+
+		wg.Wait()
+	})
+}
@@ -0,0 +1,367 @@
+package tests
+
+import (
+	"database/sql/driver"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conversion is a driver value to scan, with the expected result.
+// A nil want means an expected null, an error is expected if wantErr is set.
+type conversion struct {
+	src     any
+	want    any
+	wantErr bool
+}
+
+func checkConversions[T any](t *testing.T, conversions []conversion) {
+	t.Helper()
+
+	for _, c := range conversions {
+		t.Run(typeName(c.src), func(t *testing.T) {
+			n := nullable.Of[T]{}
+			err := n.Scan(c.src)
+
+			if c.wantErr {
+				assert.Error(t, err, "scanning %#v", c.src)
+
+				return
+			}
+
+			require.NoError(t, err, "scanning %#v", c.src)
+
+			if c.want == nil {
+				assert.True(t, n.IsNull(), "scanning %#v", c.src)
+
+				return
+			}
+
+			require.False(t, n.IsNull(), "scanning %#v", c.src)
+			assert.Equal(t, c.want, *n.GetValue(), "scanning %#v", c.src)
+		})
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "nil"
+	case int64:
+		return "int64"
+	case float64:
+		return "float64"
+	case bool:
+		return "bool"
+	case []byte:
+		return "bytes"
+	case string:
+		return "string"
+	case time.Time:
+		return "time"
+	}
+
+	return "other"
+}
+
+func TestDriverConversions(t *testing.T) {
+	ts := time.Date(2024, time.February, 29, 13, 45, 6, 500000000, time.UTC)
+	uid := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	t.Run("string", func(t *testing.T) {
+		checkConversions[string](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: "42"},
+			{src: 3.5, want: "3.5"},
+			{src: true, want: "true"},
+			{src: []byte("bytes"), want: "bytes"},
+			{src: "text", want: "text"},
+			{src: ts, want: "2024-02-29T13:45:06.5Z"},
+		})
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		checkConversions[int64](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: int64(42)},
+			{src: 42.0, want: int64(42)},
+			{src: 42.5, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("42"), want: int64(42)},
+			{src: "-42", want: int64(-42)},
+			{src: "4.2", wantErr: true},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("int", func(t *testing.T) {
+		checkConversions[int](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: 42},
+			{src: 42.0, want: 42},
+			{src: false, wantErr: true},
+			{src: []byte("42"), want: 42},
+			{src: "42", want: 42},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("int32", func(t *testing.T) {
+		checkConversions[int32](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: int32(42)},
+			{src: int64(1) << 40, wantErr: true},
+			{src: 42.0, want: int32(42)},
+			{src: true, wantErr: true},
+			{src: []byte("42"), want: int32(42)},
+			{src: "10000000000", wantErr: true},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("int16", func(t *testing.T) {
+		checkConversions[int16](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: int16(42)},
+			{src: int64(100000), wantErr: true},
+			{src: 42.0, want: int16(42)},
+			{src: true, wantErr: true},
+			{src: []byte("42"), want: int16(42)},
+			{src: "42", want: int16(42)},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		checkConversions[float64](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: 42.0},
+			{src: 3.5, want: 3.5},
+			{src: true, wantErr: true},
+			{src: []byte("3.5"), want: 3.5},
+			{src: "-1e3", want: -1000.0},
+			{src: "abc", wantErr: true},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		checkConversions[bool](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(1), want: true},
+			{src: int64(0), want: false},
+			{src: int64(2), wantErr: true},
+			{src: 1.0, wantErr: true},
+			{src: true, want: true},
+			{src: []byte("t"), want: true},
+			{src: []byte("0"), want: false},
+			{src: "true", want: true},
+			{src: "f", want: false},
+			{src: "yes", wantErr: true},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		checkConversions[uuid.UUID](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("550e8400-e29b-41d4-a716-446655440000"), want: uid},
+			{src: uid[:], want: uid},
+			{src: "550e8400e29b41d4a716446655440000", want: uid},
+			{src: "not a uuid", wantErr: true},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("time", func(t *testing.T) {
+		offset := time.FixedZone("", 2*60*60)
+
+		checkConversions[time.Time](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("2024-02-29T13:45:06.5Z"), want: ts},
+			{src: "2024-02-29 13:45:06.5", want: ts},
+			{src: "2024-02-29 15:45:06.5+02:00", want: ts.In(offset)},
+			{src: "2024-02-29 15:45:06.5+02", want: ts.In(offset)},
+			{src: []byte("2024-02-29"), want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+			{src: "2024-02-29 13:45:06.5 +0000 UTC m=+0.010007919", want: ts},
+			{src: "yesterday", wantErr: true},
+			{src: ts, want: ts},
+		})
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		checkConversions[time.Duration](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(1500), want: 1500 * time.Nanosecond},
+			{src: 1.5, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("01:00:00"), want: time.Hour},
+			{src: "PT1M", want: time.Minute},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("date", func(t *testing.T) {
+		day := nullable.Date{Year: 2024, Month: time.February, Day: 29}
+
+		checkConversions[nullable.Date](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("2024-02-29"), want: day},
+			{src: "2024-02-29 13:45:06", want: day},
+			{src: ts, want: day},
+		})
+	})
+
+	t.Run("time of day", func(t *testing.T) {
+		tod := nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 6, Nanosecond: 500000000}
+
+		checkConversions[nullable.TimeOfDay](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("13:45:06.5"), want: tod},
+			{src: "2024-02-29T13:45:06.5Z", want: tod},
+			{src: ts, want: tod},
+		})
+	})
+
+	t.Run("inet", func(t *testing.T) {
+		checkConversions[netip.Addr](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("10.0.0.1"), want: netip.MustParseAddr("10.0.0.1")},
+			{src: "::1", want: netip.MustParseAddr("::1")},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("cidr", func(t *testing.T) {
+		checkConversions[netip.Prefix](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("10.0.0.0/8"), want: netip.MustParsePrefix("10.0.0.0/8")},
+			{src: "10.0.0.0/8", want: netip.MustParsePrefix("10.0.0.0/8")},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("macaddr", func(t *testing.T) {
+		hw, err := net.ParseMAC("08:00:2b:01:02:03")
+		require.NoError(t, err)
+
+		checkConversions[net.HardwareAddr](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("08:00:2b:01:02:03"), want: hw},
+			{src: "08-00-2B-01-02-03", want: hw},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("enum", func(t *testing.T) {
+		checkConversions[status](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), wantErr: true},
+			{src: 4.2, wantErr: true},
+			{src: true, wantErr: true},
+			{src: []byte("active"), want: statusActive},
+			{src: "inactive", want: statusInactive},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("json", func(t *testing.T) {
+		checkConversions[nullable.JSON](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: nullable.JSON(float64(42))},
+			{src: 4.5, want: nullable.JSON(4.5)},
+			{src: true, want: nullable.JSON(true)},
+			{src: []byte(`{"key":"value"}`), want: nullable.JSON(map[string]any{"key": "value"})},
+			{src: `[1,"two"]`, want: nullable.JSON([]any{float64(1), "two"})},
+			{src: ts, wantErr: true},
+		})
+	})
+
+	t.Run("named type", func(t *testing.T) {
+		checkConversions[userID](t, []conversion{
+			{src: nil, want: nil},
+			{src: int64(42), want: userID(42)},
+			{src: 42.0, want: userID(42)},
+			{src: true, wantErr: true},
+			{src: []byte("42"), want: userID(42)},
+			{src: "42", want: userID(42)},
+			{src: ts, wantErr: true},
+		})
+	})
+}
+
+func TestDriverValues(t *testing.T) {
+	t.Run("integers are int64", func(t *testing.T) {
+		for _, v := range []any{
+			mustValue(t, nullable.FromValue(42)),
+			mustValue(t, nullable.FromValue(int16(42))),
+			mustValue(t, nullable.FromValue(int32(42))),
+			mustValue(t, nullable.FromValue(int64(42))),
+			mustValue(t, nullable.FromValue(userID(42))),
+		} {
+			assert.Equal(t, int64(42), v)
+		}
+	})
+
+	t.Run("all values are valid driver values", func(t *testing.T) {
+		hw, err := net.ParseMAC("08:00:2b:01:02:03")
+		require.NoError(t, err)
+
+		for _, v := range []any{
+			mustValue(t, nullable.FromValue("text")),
+			mustValue(t, nullable.FromValue(3.5)),
+			mustValue(t, nullable.FromValue(true)),
+			mustValue(t, nullable.FromValue(uuid.New())),
+			mustValue(t, nullable.FromValue(time.Now())),
+			mustValue(t, nullable.FromValue(time.Hour)),
+			mustValue(t, nullable.FromValue(nullable.Date{Year: 2024, Month: time.February, Day: 29})),
+			mustValue(t, nullable.FromValue(nullable.TimeOfDay{Hour: 12})),
+			mustValue(t, nullable.FromValue(netip.MustParseAddr("10.0.0.1"))),
+			mustValue(t, nullable.FromValue(netip.MustParsePrefix("10.0.0.0/8"))),
+			mustValue(t, nullable.FromValue(hw)),
+			mustValue(t, nullable.FromValue(statusActive)),
+			mustValue(t, nullable.FromValue[nullable.JSON](map[string]any{"key": "value"})),
+			mustValue(t, nullable.FromValue(email("john@example.com"))),
+			mustValue(t, nullable.FromValue(flag(true))),
+			mustValue(t, nullable.FromValue(score(1.5))),
+		} {
+			assert.True(t, driver.IsValue(v), "%T is not a driver value", v)
+		}
+	})
+}
+
+func mustValue[T any](t *testing.T, n nullable.Of[T]) any {
+	t.Helper()
+
+	v, err := n.Value()
+	require.NoError(t, err)
+
+	return v
+}
@@ -0,0 +1,212 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		d, err := nullable.ParseDate("2024-02-29")
+		require.NoError(t, err)
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.February, Day: 29}, d)
+	})
+
+	t.Run("parse BC", func(t *testing.T) {
+		d, err := nullable.ParseDate("0044-03-15 BC")
+		require.NoError(t, err)
+		assert.Equal(t, nullable.Date{Year: -43, Month: time.March, Day: 15}, d)
+		assert.Equal(t, "0044-03-15 BC", d.String())
+	})
+
+	t.Run("parse invalid", func(t *testing.T) {
+		for _, input := range []string{"", "2024-02-30", "2024-2-3", "24-02-03", "2024-02-03T00:00:00Z", "+024-02-03"} {
+			_, err := nullable.ParseDate(input)
+			var parseErr *nullable.ParseError
+			require.ErrorAs(t, err, &parseErr, input)
+			assert.Equal(t, "nullable.Date", parseErr.Type)
+		}
+	})
+
+	t.Run("conversions with time.Time", func(t *testing.T) {
+		paris, err := time.LoadLocation("Europe/Paris")
+		require.NoError(t, err)
+
+		instant := time.Date(2024, time.March, 10, 23, 30, 0, 0, time.UTC)
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.March, Day: 10}, nullable.DateOf(instant))
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.March, Day: 11}, nullable.DateOf(instant.In(paris)))
+
+		d := nullable.Date{Year: 2024, Month: time.March, Day: 11}
+		assert.Equal(t, time.Date(2024, time.March, 11, 0, 0, 0, 0, paris), d.In(paris))
+	})
+}
+
+func TestTimeOfDay(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		tod, err := nullable.ParseTimeOfDay("13:45:06.123456")
+		require.NoError(t, err)
+		assert.Equal(t, nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 6, Nanosecond: 123456000}, tod)
+		assert.Equal(t, "13:45:06.123456", tod.String())
+	})
+
+	t.Run("parse without seconds", func(t *testing.T) {
+		tod, err := nullable.ParseTimeOfDay("13:45")
+		require.NoError(t, err)
+		assert.Equal(t, "13:45:00", tod.String())
+	})
+
+	t.Run("parse end of day", func(t *testing.T) {
+		tod, err := nullable.ParseTimeOfDay("24:00:00")
+		require.NoError(t, err)
+		assert.Equal(t, nullable.TimeOfDay{Hour: 24}, tod)
+	})
+
+	t.Run("parse invalid", func(t *testing.T) {
+		for _, input := range []string{"", "24:00:01", "13:60:00", "1:02:03", "13:45:06.", "13:45.5", "13:45:06+02"} {
+			_, err := nullable.ParseTimeOfDay(input)
+			var parseErr *nullable.ParseError
+			require.ErrorAs(t, err, &parseErr, input)
+			assert.Equal(t, "nullable.TimeOfDay", parseErr.Type)
+		}
+	})
+
+	t.Run("conversions with time.Time", func(t *testing.T) {
+		paris, err := time.LoadLocation("Europe/Paris")
+		require.NoError(t, err)
+
+		instant := time.Date(2024, time.March, 10, 23, 30, 0, 5, time.UTC)
+		assert.Equal(t, nullable.TimeOfDay{Hour: 23, Minute: 30, Nanosecond: 5}, nullable.TimeOfDayOf(instant))
+		assert.Equal(t, nullable.TimeOfDay{Hour: 0, Minute: 30, Nanosecond: 5}, nullable.TimeOfDayOf(instant.In(paris)))
+
+		tod := nullable.TimeOfDay{Hour: 0, Minute: 30, Nanosecond: 5}
+		assert.True(t, instant.Equal(tod.On(nullable.Date{Year: 2024, Month: time.March, Day: 11}, paris)))
+	})
+}
+
+func TestScanValue_DateAndTimeOfDay(t *testing.T) {
+	t.Run("scan date from time.Time", func(t *testing.T) {
+		var n nullable.Of[nullable.Date]
+		require.NoError(t, n.Scan(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.February, Day: 29}, *n.GetValue())
+	})
+
+	t.Run("scan date from text", func(t *testing.T) {
+		var n nullable.Of[nullable.Date]
+		require.NoError(t, n.Scan([]byte("2024-02-29")))
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.February, Day: 29}, *n.GetValue())
+	})
+
+	t.Run("scan date from timestamp text", func(t *testing.T) {
+		var n nullable.Of[nullable.Date]
+		require.NoError(t, n.Scan("2024-02-29 00:00:00"))
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.February, Day: 29}, *n.GetValue())
+	})
+
+	t.Run("scan invalid date", func(t *testing.T) {
+		var n nullable.Of[nullable.Date]
+		assert.Error(t, n.Scan("not a date"))
+		assert.Error(t, n.Scan(int64(42)))
+	})
+
+	t.Run("scan null date", func(t *testing.T) {
+		n := nullable.FromValue(nullable.Date{Year: 2024, Month: time.February, Day: 29})
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("scan time of day from text", func(t *testing.T) {
+		var n nullable.Of[nullable.TimeOfDay]
+		require.NoError(t, n.Scan("13:45:06.5"))
+		assert.Equal(t, nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 6, Nanosecond: 500000000}, *n.GetValue())
+	})
+
+	t.Run("scan time of day from time.Time", func(t *testing.T) {
+		var n nullable.Of[nullable.TimeOfDay]
+		require.NoError(t, n.Scan(time.Date(0, time.January, 1, 13, 45, 6, 0, time.UTC)))
+		assert.Equal(t, nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 6}, *n.GetValue())
+	})
+
+	t.Run("scan time of day from timestamp text", func(t *testing.T) {
+		var n nullable.Of[nullable.TimeOfDay]
+		require.NoError(t, n.Scan([]byte("0000-01-01T13:45:06Z")))
+		assert.Equal(t, nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 6}, *n.GetValue())
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := nullable.FromValue(nullable.Date{Year: 2024, Month: time.February, Day: 29}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "2024-02-29", v)
+
+		v, err = nullable.FromValue(nullable.TimeOfDay{Hour: 7, Minute: 5, Nanosecond: 1000}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "07:05:00.000001", v)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := nullable.FromValue(nullable.Date{}).Value()
+		assert.Error(t, err)
+
+		_, err = nullable.FromValue(nullable.TimeOfDay{Hour: 25}).Value()
+		assert.Error(t, err)
+	})
+}
+
+func TestMarshalUnmarshal_DateAndTimeOfDay(t *testing.T) {
+	type event struct {
+		Day   nullable.Of[nullable.Date]      `json:"day"`
+		Start nullable.Of[nullable.TimeOfDay] `json:"start"`
+		End   nullable.Of[nullable.TimeOfDay] `json:"end"`
+	}
+
+	e := event{
+		Day:   nullable.FromValue(nullable.Date{Year: 2024, Month: time.February, Day: 29}),
+		Start: nullable.FromValue(nullable.TimeOfDay{Hour: 9, Minute: 30}),
+		End:   nullable.Null[nullable.TimeOfDay](),
+	}
+
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"day":"2024-02-29","start":"09:30:00","end":null}`, string(data))
+
+	var restored event
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, *e.Day.GetValue(), *restored.Day.GetValue())
+	assert.Equal(t, *e.Start.GetValue(), *restored.Start.GetValue())
+	assert.True(t, restored.End.IsNull())
+
+	t.Run("invalid", func(t *testing.T) {
+		var n nullable.Of[nullable.Date]
+		var parseErr *nullable.ParseError
+		assert.ErrorAs(t, json.Unmarshal([]byte(`"2024-02-30"`), &n), &parseErr)
+	})
+}
+
+func TestDateAndTimeOfDay(t *testing.T) {
+	db := getDB(t)
+
+	t.Run("Reading date and time output", func(t *testing.T) {
+		var d nullable.Of[nullable.Date]
+		var tod nullable.Of[nullable.TimeOfDay]
+		err := db.QueryRow("SELECT '2024-02-29'::date, '13:45:06.123456'::time").Scan(&d, &tod)
+		require.NoError(t, err)
+		assert.Equal(t, nullable.Date{Year: 2024, Month: time.February, Day: 29}, *d.GetValue())
+		assert.Equal(t, nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 6, Nanosecond: 123456000}, *tod.GetValue())
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		d := nullable.FromValue(nullable.Date{Year: 1999, Month: time.December, Day: 31})
+		tod := nullable.FromValue(nullable.TimeOfDay{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999999000})
+
+		var readDate nullable.Of[nullable.Date]
+		var readTime nullable.Of[nullable.TimeOfDay]
+		err := db.QueryRow("SELECT $1::date, $2::time", &d, &tod).Scan(&readDate, &readTime)
+		require.NoError(t, err)
+		assert.Equal(t, *d.GetValue(), *readDate.GetValue())
+		assert.Equal(t, *tod.GetValue(), *readTime.GetValue())
+	})
+}
@@ -0,0 +1,250 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Duration
+	}{
+		{"postgres zero", "00:00:00", 0},
+		{"postgres time", "02:03:04", 2*time.Hour + 3*time.Minute + 4*time.Second},
+		{"postgres day and time", "1 day 02:03:04", 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{"postgres days", "3 days", 72 * time.Hour},
+		{"postgres mixed signs", "-1 days +02:03:00", -22*time.Hour + 3*time.Minute},
+		{"postgres negative time", "-02:03:04", -(2*time.Hour + 3*time.Minute + 4*time.Second)},
+		{"postgres fractional seconds", "00:00:01.5", 1500 * time.Millisecond},
+		{"postgres microseconds", "00:00:00.000001", time.Microsecond},
+		{"postgres zero months", "0 mons 2 days", 48 * time.Hour},
+		{"postgres verbose", "@ 1 day 2 hours 3 mins 4.5 secs", 26*time.Hour + 3*time.Minute + 4500*time.Millisecond},
+		{"postgres verbose ago", "@ 2 hours ago", -2 * time.Hour},
+		{"sql standard day time", "1 2:03:04", 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{"sql standard negative day time", "-1 2:03:04", -(26*time.Hour + 3*time.Minute + 4*time.Second)},
+		{"sql standard negative day", "-1 0:00:00", -24 * time.Hour},
+		{"sql standard negative", "-1 -2:03:04", -(26*time.Hour + 3*time.Minute + 4*time.Second)},
+		{"sql standard zero year-month", "+0-0 +1 +2:03:04", 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{"sql standard time", "2:03:04", 2*time.Hour + 3*time.Minute + 4*time.Second},
+		{"iso 8601", "P1DT2H", 26 * time.Hour},
+		{"iso 8601 full", "P1DT2H3M4.5S", 26*time.Hour + 3*time.Minute + 4500*time.Millisecond},
+		{"iso 8601 negative components", "P-1DT-2H", -26 * time.Hour},
+		{"iso 8601 negative", "-PT1H", -time.Hour},
+		{"iso 8601 weeks", "P2W", 14 * 24 * time.Hour},
+		{"iso 8601 zero", "PT0S", 0},
+		{"bare seconds", "90", 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := nullable.ParseInterval(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+
+	t.Run("month-based intervals are rejected", func(t *testing.T) {
+		for _, input := range []string{"1 mon", "1 year 2 mons 3 days", "1-2", "-1-0 3 4:05:06", "P1M", "P1Y2DT3H"} {
+			_, err := nullable.ParseInterval(input)
+			require.ErrorIs(t, err, nullable.ErrInexactInterval, input)
+
+			var parseErr *nullable.ParseError
+			require.ErrorAs(t, err, &parseErr, input)
+			assert.Equal(t, "time.Duration", parseErr.Type)
+		}
+	})
+
+	t.Run("invalid intervals", func(t *testing.T) {
+		for _, input := range []string{"", "not an interval", "1:2:3:4", "P", "PT", "-PT", "P1DT", "PT1HT1M", "PT1X", "P1H", "00:00:01.5e3", "99999999999 days"} {
+			_, err := nullable.ParseInterval(input)
+			assert.Error(t, err, input)
+		}
+	})
+}
+
+func TestFormatInterval(t *testing.T) {
+	assert.Equal(t, "0:00:00", nullable.FormatInterval(0))
+	assert.Equal(t, "26:03:04.5", nullable.FormatInterval(26*time.Hour+3*time.Minute+4500*time.Millisecond))
+	assert.Equal(t, "-0:00:00.000000001", nullable.FormatInterval(-time.Nanosecond))
+	assert.Equal(t, "-2562047:47:16.854775808", nullable.FormatInterval(time.Duration(-1<<63)))
+
+	assert.Equal(t, "PT0S", nullable.FormatISO8601Duration(0))
+	assert.Equal(t, "PT26H3M4.5S", nullable.FormatISO8601Duration(26*time.Hour+3*time.Minute+4500*time.Millisecond))
+	assert.Equal(t, "-PT1H", nullable.FormatISO8601Duration(-time.Hour))
+	assert.Equal(t, "PT2M", nullable.FormatISO8601Duration(2*time.Minute))
+}
+
+func TestScanValue_Duration(t *testing.T) {
+	t.Run("scan interval text", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		require.NoError(t, n.Scan("1 day 02:03:04"))
+		assert.Equal(t, 26*time.Hour+3*time.Minute+4*time.Second, *n.GetValue())
+	})
+
+	t.Run("scan interval bytes", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		require.NoError(t, n.Scan([]byte("PT1H")))
+		assert.Equal(t, time.Hour, *n.GetValue())
+	})
+
+	t.Run("scan nanoseconds", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		require.NoError(t, n.Scan(int64(1500)))
+		assert.Equal(t, 1500*time.Nanosecond, *n.GetValue())
+	})
+
+	t.Run("scan null", func(t *testing.T) {
+		n := nullable.FromValue(time.Hour)
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("scan month interval", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		assert.ErrorIs(t, n.Scan("1 mon"), nullable.ErrInexactInterval)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		v, err := nullable.FromValue(26*time.Hour + 3*time.Minute + 4*time.Second).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "26:03:04", v)
+	})
+
+	t.Run("value null", func(t *testing.T) {
+		v, err := nullable.Null[time.Duration]().Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+}
+
+func TestMarshalUnmarshal_Duration(t *testing.T) {
+	d := 26*time.Hour + 3*time.Minute + 4500*time.Millisecond
+
+	formats := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"number", nullable.FromValue(d), "93784500000000"},
+		{"go string", nullable.FromValue(nullable.StringDuration(d)), `"26h3m4.5s"`},
+		{"iso 8601", nullable.FromValue(nullable.ISODuration(d)), `"PT26H3M4.5S"`},
+	}
+
+	for _, f := range formats {
+		t.Run(f.name, func(t *testing.T) {
+			data, err := json.Marshal(f.value)
+			require.NoError(t, err)
+			assert.Equal(t, f.expected, string(data))
+
+			var n nullable.Of[time.Duration]
+			require.NoError(t, json.Unmarshal(data, &n))
+			assert.Equal(t, d, *n.GetValue())
+
+			var iso nullable.Of[nullable.ISODuration]
+			require.NoError(t, json.Unmarshal(data, &iso))
+			assert.Equal(t, nullable.ISODuration(d), *iso.GetValue())
+
+			var str nullable.StringDuration
+			require.NoError(t, json.Unmarshal(data, &str))
+			assert.Equal(t, nullable.StringDuration(d), str)
+		})
+	}
+
+	t.Run("per value", func(t *testing.T) {
+		type row struct {
+			Timeout nullable.Of[time.Duration]        `json:"timeout"`
+			Delay   nullable.Of[nullable.ISODuration] `json:"delay"`
+		}
+
+		data, err := json.Marshal(row{
+			Timeout: nullable.FromValue(time.Second), Delay: nullable.FromValue(nullable.ISODuration(d)),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"timeout": 1000000000, "delay": "PT26H3M4.5S"}`, string(data))
+
+		data, err = json.Marshal(row{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"timeout": null, "delay": null}`, string(data))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		data, err := json.Marshal(nullable.Null[time.Duration]())
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+
+		var n nullable.Of[time.Duration]
+		require.NoError(t, json.Unmarshal(data, &n))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("invalid string", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		var parseErr *nullable.ParseError
+		assert.ErrorAs(t, json.Unmarshal([]byte(`"5"`), &n), &parseErr)
+	})
+
+	t.Run("month-based ISO 8601", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"P1M"`), &n), nullable.ErrInexactInterval)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		assert.Error(t, json.Unmarshal([]byte(`true`), &n))
+	})
+}
+
+func TestScanValue_NamedDurations(t *testing.T) {
+	d := 26*time.Hour + 3*time.Minute + 4500*time.Millisecond
+
+	iso := nullable.FromValue(nullable.ISODuration(d))
+	v, err := iso.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "26:03:04.5", v)
+
+	var scanned nullable.Of[nullable.ISODuration]
+	require.NoError(t, scanned.Scan("1 day 02:03:04.5"))
+	assert.Equal(t, nullable.ISODuration(d), *scanned.GetValue())
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.True(t, scanned.IsNull())
+
+	var str nullable.StringDuration
+	require.NoError(t, str.Scan(int64(d)))
+	assert.Equal(t, nullable.StringDuration(d), str)
+	assert.Error(t, str.Scan(nil), "a StringDuration can't be null")
+	assert.ErrorIs(t, str.Scan("1 mon"), nullable.ErrInexactInterval)
+}
+
+func TestInterval(t *testing.T) {
+	db := getDB(t)
+
+	t.Run("Reading interval output", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		err := db.QueryRow("SELECT '1 day 02:03:04.5'::interval").Scan(&n)
+		require.NoError(t, err)
+		assert.Equal(t, 26*time.Hour+3*time.Minute+4500*time.Millisecond, *n.GetValue())
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		d := nullable.FromValue(-(50*time.Hour + time.Microsecond))
+
+		var n nullable.Of[time.Duration]
+		err := db.QueryRow("SELECT $1::interval", &d).Scan(&n)
+		require.NoError(t, err)
+		assert.Equal(t, *d.GetValue(), *n.GetValue())
+	})
+
+	t.Run("Month-based interval", func(t *testing.T) {
+		var n nullable.Of[time.Duration]
+		err := db.QueryRow("SELECT '1 mon'::interval").Scan(&n)
+		assert.ErrorIs(t, err, nullable.ErrInexactInterval)
+	})
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type status string
+
+const (
+	statusActive   status = "active"
+	statusInactive status = "inactive"
+)
+
+func (status) Values() []string {
+	return []string{string(statusActive), string(statusInactive)}
+}
+
+// notStringEnum is an invalid Enum since its underlying type is not string
+type notStringEnum int
+
+func (notStringEnum) Values() []string {
+	return []string{"1"}
+}
+
+func TestScanValue_Enum(t *testing.T) {
+	t.Run("scan string", func(t *testing.T) {
+		var n nullable.Of[status]
+		require.NoError(t, n.Scan("active"))
+		assert.Equal(t, statusActive, *n.GetValue())
+	})
+
+	t.Run("scan bytes", func(t *testing.T) {
+		var n nullable.Of[status]
+		require.NoError(t, n.Scan([]byte("inactive")))
+		assert.Equal(t, statusInactive, *n.GetValue())
+	})
+
+	t.Run("scan null", func(t *testing.T) {
+		n := nullable.FromValue(statusActive)
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("scan unknown value", func(t *testing.T) {
+		var n nullable.Of[status]
+		err := n.Scan("deleted")
+		require.ErrorIs(t, err, nullable.ErrInvalidEnumValue)
+
+		var parseErr *nullable.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "tests.status", parseErr.Type)
+		assert.Equal(t, "deleted", parseErr.Input)
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("scan JSON-encoded value", func(t *testing.T) {
+		var n nullable.Of[status]
+		assert.ErrorIs(t, n.Scan(`"active"`), nullable.ErrInvalidEnumValue)
+	})
+
+	t.Run("value is a plain string", func(t *testing.T) {
+		v, err := nullable.FromValue(statusActive).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "active", v)
+	})
+
+	t.Run("value unknown", func(t *testing.T) {
+		_, err := nullable.FromValue(status("deleted")).Value()
+		assert.ErrorIs(t, err, nullable.ErrInvalidEnumValue)
+	})
+
+	t.Run("not string-backed", func(t *testing.T) {
+		var n nullable.Of[notStringEnum]
+		assert.Error(t, n.Scan("1"))
+
+		_, err := nullable.FromValue(notStringEnum(1)).Value()
+		assert.Error(t, err)
+	})
+}
+
+func TestMarshalUnmarshal_Enum(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		data, err := json.Marshal(nullable.FromValue(statusInactive))
+		require.NoError(t, err)
+		assert.Equal(t, `"inactive"`, string(data))
+
+		var n nullable.Of[status]
+		require.NoError(t, json.Unmarshal(data, &n))
+		assert.Equal(t, statusInactive, *n.GetValue())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var n nullable.Of[status]
+		require.NoError(t, json.Unmarshal([]byte("null"), &n))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("unknown value", func(t *testing.T) {
+		var n nullable.Of[status]
+		assert.ErrorIs(t, json.Unmarshal([]byte(`"deleted"`), &n), nullable.ErrInvalidEnumValue)
+	})
+
+	t.Run("not a string", func(t *testing.T) {
+		var n nullable.Of[status]
+		assert.Error(t, json.Unmarshal([]byte(`1`), &n))
+	})
+}
+
+func TestEnum(t *testing.T) {
+	db := getDB(t)
+	cleanupTables(t, db, "enum_test")
+
+	active := nullable.FromValue(statusActive)
+	null := nullable.Null[status]()
+
+	var insertedID int64
+	t.Run("Writing data into database", func(t *testing.T) {
+		err := db.QueryRow(
+			"INSERT INTO enum_test (status_val, other_status_val) VALUES ($1, $2) RETURNING id",
+			&active, &null,
+		).Scan(&insertedID)
+		require.NoError(t, err, "Insert enum failed")
+	})
+
+	t.Run("Reading data from database", func(t *testing.T) {
+		var readActive, readNull nullable.Of[status]
+		err := db.QueryRow(
+			"SELECT status_val, other_status_val FROM enum_test WHERE id = $1",
+			insertedID,
+		).Scan(&readActive, &readNull)
+		require.NoError(t, err, "Read enum failed")
+
+		assert.Equal(t, statusActive, *readActive.GetValue())
+		assert.True(t, readNull.IsNull())
+	})
+
+	t.Run("Reading unknown value", func(t *testing.T) {
+		var n nullable.Of[status]
+		err := db.QueryRow("SELECT 'suspended'::status").Scan(&n)
+		assert.ErrorIs(t, err, nullable.ErrInvalidEnumValue)
+	})
+}
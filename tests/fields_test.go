@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditBase struct {
+	CreatedBy nullable.Of[string]    `db:"created_by" json:"createdBy,omitempty"`
+	DeletedAt nullable.Of[time.Time] `db:"deleted_at" json:"-"`
+}
+
+type Owner struct {
+	OwnerID nullable.Of[int64] `db:"owner_id"`
+	Note    nullable.Of[string]
+}
+
+type account struct {
+	auditBase
+	*Owner
+	ID      int64               `db:"id" json:"id"`
+	Name    nullable.Of[string] `db:"name" json:"name"`
+	Note    string              // hides Owner.Note
+	Balance NullAmount          `db:"balance" json:"balance"`
+	secret  nullable.Of[string]
+}
+
+type recursive struct {
+	*recursive
+	Next  *recursive
+	Value nullable.Of[int] `json:"value"`
+}
+
+func TestFields(t *testing.T) {
+	t.Run("Layout and values", func(t *testing.T) {
+		a := account{ID: 1, Name: nullable.FromValue("main"), secret: nullable.FromValue("s")}
+		a.CreatedBy.SetValue("admin")
+		a.Balance.SetValue(100)
+
+		fields, err := nullable.Fields(a)
+		require.NoError(t, err)
+
+		want := []nullable.Field{
+			{
+				Name: "CreatedBy", Index: []int{0, 0}, DBName: "created_by", JSONName: "createdBy",
+				Type: reflect.TypeFor[nullable.Of[string]](), ValueType: reflect.TypeFor[string](),
+				Value: "admin",
+			},
+			{
+				Name: "DeletedAt", Index: []int{0, 1}, DBName: "deleted_at", JSONName: "-",
+				Type: reflect.TypeFor[nullable.Of[time.Time]](), ValueType: reflect.TypeFor[time.Time](),
+				IsNull: true,
+			},
+			{
+				Name: "OwnerID", Index: []int{1, 0}, DBName: "owner_id",
+				Type: reflect.TypeFor[nullable.Of[int64]](), ValueType: reflect.TypeFor[int64](),
+				IsNull: true,
+			},
+			{
+				Name: "Name", Index: []int{3}, DBName: "name", JSONName: "name",
+				Type: reflect.TypeFor[nullable.Of[string]](), ValueType: reflect.TypeFor[string](),
+				Value: "main",
+			},
+			{
+				Name: "Balance", Index: []int{5}, DBName: "balance", JSONName: "balance",
+				Type: reflect.TypeFor[NullAmount](), ValueType: reflect.TypeFor[amount](),
+				Value: amount(100),
+			},
+		}
+		assert.Equal(t, want, fields)
+	})
+
+	t.Run("Pointer and embedded pointer", func(t *testing.T) {
+		a := &account{Owner: &Owner{OwnerID: nullable.FromValue(int64(7))}}
+
+		fields, err := nullable.Fields(a)
+		require.NoError(t, err)
+		require.Len(t, fields, 5)
+
+		assert.Equal(t, "OwnerID", fields[2].Name)
+		assert.False(t, fields[2].IsNull)
+		assert.Equal(t, int64(7), fields[2].Value)
+		assert.True(t, fields[3].IsNull, "Name should be null")
+		assert.Nil(t, fields[3].Value)
+	})
+
+	t.Run("Cached layout", func(t *testing.T) {
+		first, err := nullable.Fields(account{})
+		require.NoError(t, err)
+
+		second, err := nullable.Fields(&account{Name: nullable.FromValue("x")})
+		require.NoError(t, err)
+
+		assert.Equal(t, first[3].Index, second[3].Index)
+		assert.True(t, first[3].IsNull, "the values should not be cached")
+		assert.Equal(t, "x", second[3].Value)
+	})
+
+	t.Run("Recursive embedding", func(t *testing.T) {
+		r := recursive{Value: nullable.FromValue(1), recursive: &recursive{Value: nullable.FromValue(2)}}
+
+		fields, err := nullable.Fields(r)
+		require.NoError(t, err)
+		require.Len(t, fields, 1, "the embedded Value should be hidden")
+		assert.Equal(t, 1, fields[0].Value)
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		_, err := nullable.Fields(42)
+		require.ErrorIs(t, err, nullable.ErrNotStruct)
+
+		_, err = nullable.Fields((*account)(nil))
+		require.ErrorIs(t, err, nullable.ErrNotStruct)
+
+		_, err = nullable.Fields(nil)
+		require.ErrorIs(t, err, nullable.ErrNotStruct)
+	})
+}
+
+func TestWalkFields(t *testing.T) {
+	t.Run("Embedded structs", func(t *testing.T) {
+		var names []string
+
+		var indexes [][]int
+
+		nullable.WalkFields(reflect.TypeFor[account](), func(sf reflect.StructField) bool {
+			names = append(names, sf.Name)
+			indexes = append(indexes, sf.Index)
+
+			return sf.Name != "Owner"
+		})
+
+		assert.Equal(t, []string{"auditBase", "CreatedBy", "DeletedAt", "Owner", "ID", "Name", "Note", "Balance", "secret"},
+			names, "the fields of Owner should not be walked")
+		assert.Equal(t, []int{0, 1}, indexes[2])
+		assert.Equal(t, []int{3}, indexes[5])
+	})
+
+	t.Run("Recursive embedding", func(t *testing.T) {
+		var names []string
+
+		nullable.WalkFields(reflect.TypeFor[recursive](), func(sf reflect.StructField) bool {
+			names = append(names, sf.Name)
+
+			return true
+		})
+
+		assert.Equal(t, []string{"recursive", "Next", "Value"}, names, "recursive should be walked once")
+	})
+}
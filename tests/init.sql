@@ -21,6 +21,23 @@ CREATE TABLE IF NOT EXISTS type_test (
     json_val JSONB
 );
 
+-- Create test table for network address types
+CREATE TABLE IF NOT EXISTS net_test (
+    id SERIAL PRIMARY KEY,
+    inet_val INET,
+    cidr_val CIDR,
+    mac_val MACADDR
+);
+
+-- Create test table for enum types
+CREATE TYPE status AS ENUM ('active', 'inactive', 'suspended');
+
+CREATE TABLE IF NOT EXISTS enum_test (
+    id SERIAL PRIMARY KEY,
+    status_val status,
+    other_status_val status
+);
+
 -- Insert some test data
 INSERT INTO test (name, date_to, data) VALUES
     ('Test 1', NOW(), '{"string": "value 1", "bool": true, "int": 42}'::jsonb),
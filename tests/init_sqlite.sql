@@ -0,0 +1,41 @@
+-- SQLite equivalent of init.sql
+
+-- Create test table
+CREATE TABLE IF NOT EXISTS test (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255),
+    date_to TIMESTAMP,
+    data TEXT
+);
+
+-- Create additional test table for various types
+CREATE TABLE IF NOT EXISTS type_test (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    string_val VARCHAR(255),
+    int_val INTEGER,
+    int16_val SMALLINT,
+    int32_val INTEGER,
+    int64_val BIGINT,
+    float_val DOUBLE PRECISION,
+    bool_val BOOLEAN,
+    uuid_val TEXT,
+    time_val TIMESTAMP,
+    json_val TEXT
+);
+
+-- Insert some test data
+INSERT INTO test (name, date_to, data) VALUES
+    ('Test 1', CURRENT_TIMESTAMP, '{"string": "value 1", "bool": true, "int": 42}'),
+    (NULL, NULL, NULL),
+    ('Test 3', '2025-12-31 23:59:59', '{"string": "value 2", "bool": true, "int": 42}');
+
+-- Create test table for types stored as text by SQLite
+CREATE TABLE IF NOT EXISTS text_type_test (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    date_val DATE,
+    time_val TIME,
+    duration_val TEXT,
+    addr_val TEXT,
+    prefix_val TEXT,
+    status_val TEXT CHECK (status_val IN ('active', 'inactive', 'suspended'))
+);
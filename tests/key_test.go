@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"hash/maphash"
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualMethod(t *testing.T) {
+	t.Run("Scalars", func(t *testing.T) {
+		assert.True(t, nullable.FromValue(5).Equal(nullable.FromValue(5)), "values are compared, not pointers")
+		assert.False(t, nullable.FromValue(5).Equal(nullable.FromValue(6)))
+		assert.False(t, nullable.FromValue(5).Equal(nullable.Null[int]()))
+		assert.True(t, nullable.Null[int]().Equal(nullable.Null[int]()), "null IS NOT DISTINCT FROM null")
+
+		id := uuid.New()
+		assert.True(t, nullable.FromValue(id).Equal(nullable.FromValue(id)))
+		assert.True(t, nullable.FromValue(netip.MustParseAddr("::1")).Equal(nullable.FromValue(netip.IPv6Loopback())))
+		assert.True(t, nullable.FromValue(statusActive).Equal(nullable.FromValue(status("active"))))
+	})
+
+	t.Run("Time", func(t *testing.T) {
+		utc := time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)
+		local := utc.In(time.FixedZone("", 2*60*60))
+
+		assert.True(t, nullable.FromValue(utc).Equal(nullable.FromValue(local)), "same instant")
+		assert.False(t, nullable.FromValue(utc).Equal(nullable.FromValue(utc.Add(time.Nanosecond))))
+		assert.True(t, nullable.FromValue(time.Now()).Key() != nil)
+	})
+
+	t.Run("JSON payloads", func(t *testing.T) {
+		a := nullable.FromValue[nullable.JSON](map[string]any{"b": []any{1, "x"}, "a": map[string]any{"z": true, "y": nil}})
+		b := nullable.FromValue[nullable.JSON](map[string]any{"a": map[string]any{"y": nil, "z": true}, "b": []any{1, "x"}})
+		c := nullable.FromValue[nullable.JSON](map[string]any{"a": 1})
+
+		assert.True(t, a.Equal(b))
+		assert.False(t, a.Equal(c))
+
+		assert.False(t, nullable.FromValue[nullable.JSON](`{"a":1}`).Equal(c), "a string is not equal to an object")
+		assert.False(t, nullable.FromValue[nullable.JSON](nil).Equal(nullable.Null[nullable.JSON]()), "JSON null is a value")
+	})
+
+	t.Run("Structs", func(t *testing.T) {
+		x := nullable.FromValue(getEmbeddedObj())
+		y := nullable.FromValue(getEmbeddedObj())
+
+		assert.True(t, x.Equal(y), "nested nullable fields are compared by value")
+
+		z := getEmbeddedObj()
+		z.Bool.SetValue(false)
+		assert.False(t, x.Equal(nullable.FromValue(z)))
+	})
+
+	t.Run("Compact", func(t *testing.T) {
+		values := []nullable.Of[int]{
+			nullable.FromValue(1), nullable.FromValue(1), nullable.Null[int](), nullable.Null[int](), nullable.FromValue(2),
+		}
+
+		assert.Equal(t,
+			[]nullable.Of[int]{nullable.FromValue(1), nullable.Null[int](), nullable.FromValue(2)},
+			slices.CompactFunc(values, nullable.Of[int].Equal),
+		)
+	})
+}
+
+func TestKey(t *testing.T) {
+	t.Run("Map keys", func(t *testing.T) {
+		seen := map[any]int{}
+
+		for _, n := range []nullable.Of[int]{
+			nullable.FromValue(5), nullable.FromValue(5), nullable.Null[int](), nullable.FromValue(7), nullable.Null[int](),
+		} {
+			seen[n.Key()]++
+		}
+
+		assert.Equal(t, map[any]int{5: 2, 7: 1, nil: 2}, seen)
+	})
+
+	t.Run("JSON payloads", func(t *testing.T) {
+		seen := map[any]int{}
+
+		for _, n := range []nullable.Of[nullable.JSON]{
+			nullable.FromValue[nullable.JSON](map[string]any{"a": 1, "b": 2}),
+			nullable.FromValue[nullable.JSON](map[string]any{"b": 2, "a": 1}),
+			nullable.FromValue[nullable.JSON]([]any{1, 2}),
+		} {
+			seen[n.Key()]++
+		}
+
+		assert.Len(t, seen, 2)
+	})
+
+	t.Run("Hash", func(t *testing.T) {
+		seed := maphash.MakeSeed()
+
+		assert.Equal(t, nullable.FromValue("a").Hash(seed), nullable.FromValue("a").Hash(seed))
+		assert.Equal(t, nullable.Null[string]().Hash(seed), nullable.Null[string]().Hash(seed))
+		assert.NotEqual(t, nullable.FromValue("a").Hash(seed), nullable.FromValue("b").Hash(seed))
+
+		payload := nullable.FromValue[nullable.JSON](map[string]any{"a": []any{1}})
+		assert.Equal(t, payload.Hash(seed), nullable.FromValue[nullable.JSON](map[string]any{"a": []any{1}}).Hash(seed))
+	})
+}
@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	userID    int64
+	email     string
+	score     float64
+	flag      bool
+	smallID   int16
+	mediumID  int32
+	counterID int
+)
+
+// upperString is stored in uppercase thanks to its own sql.Scanner and driver.Valuer
+type upperString string
+
+func (u upperString) Value() (driver.Value, error) {
+	return strings.ToUpper(string(u)), nil
+}
+
+func (u *upperString) Scan(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan %T", v)
+	}
+
+	*u = upperString(strings.ToLower(s))
+
+	return nil
+}
+
+func TestScanValue_NamedTypes(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		var n nullable.Of[userID]
+		require.NoError(t, n.Scan(int64(42)))
+		assert.Equal(t, userID(42), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), v)
+	})
+
+	t.Run("int16", func(t *testing.T) {
+		var n nullable.Of[smallID]
+		require.NoError(t, n.Scan(int64(16)))
+		assert.Equal(t, smallID(16), *n.GetValue())
+
+		assert.Error(t, n.Scan(int64(100000)), "overflow must be detected as for int16")
+	})
+
+	t.Run("int32", func(t *testing.T) {
+		var n nullable.Of[mediumID]
+		require.NoError(t, n.Scan(int64(32)))
+		assert.Equal(t, mediumID(32), *n.GetValue())
+	})
+
+	t.Run("int", func(t *testing.T) {
+		var n nullable.Of[counterID]
+		require.NoError(t, n.Scan(int64(7)))
+		assert.Equal(t, counterID(7), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), v)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		var n nullable.Of[email]
+		require.NoError(t, n.Scan([]byte("john@example.com")))
+		assert.Equal(t, email("john@example.com"), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "john@example.com", v)
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		var n nullable.Of[score]
+		require.NoError(t, n.Scan(3.5))
+		assert.Equal(t, score(3.5), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, 3.5, v)
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		var n nullable.Of[flag]
+		require.NoError(t, n.Scan(true))
+		assert.Equal(t, flag(true), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, true, v)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		n := nullable.FromValue(userID(42))
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		var n nullable.Of[userID]
+		assert.Error(t, n.Scan("not a number"))
+	})
+
+	t.Run("own scanner and valuer take precedence", func(t *testing.T) {
+		var n nullable.Of[upperString]
+		require.NoError(t, n.Scan("HELLO"))
+		assert.Equal(t, upperString("hello"), *n.GetValue())
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "HELLO", v)
+	})
+}
+
+func TestMarshalUnmarshal_NamedTypes(t *testing.T) {
+	type account struct {
+		ID    nullable.Of[userID] `json:"id"`
+		Email nullable.Of[email]  `json:"email"`
+		Score nullable.Of[score]  `json:"score"`
+		Admin nullable.Of[flag]   `json:"admin"`
+	}
+
+	a := account{
+		ID:    nullable.FromValue(userID(42)),
+		Email: nullable.FromValue(email("john@example.com")),
+		Score: nullable.Null[score](),
+		Admin: nullable.FromValue(flag(false)),
+	}
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":42,"email":"john@example.com","score":null,"admin":false}`, string(data))
+
+	var restored account
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, userID(42), *restored.ID.GetValue())
+	assert.Equal(t, email("john@example.com"), *restored.Email.GetValue())
+	assert.True(t, restored.Score.IsNull())
+	assert.Equal(t, flag(false), *restored.Admin.GetValue())
+}
+
+func TestNamedTypes(t *testing.T) {
+	db := getDB(t)
+
+	id := nullable.FromValue(userID(42))
+	mail := nullable.FromValue(email("john@example.com"))
+	small := nullable.Null[smallID]()
+
+	var readID nullable.Of[userID]
+	var readMail nullable.Of[email]
+	var readSmall nullable.Of[smallID]
+
+	err := db.QueryRow("SELECT $1::bigint, $2::text, $3::smallint", &id, &mail, &small).
+		Scan(&readID, &readMail, &readSmall)
+	require.NoError(t, err)
+
+	assert.Equal(t, userID(42), *readID.GetValue())
+	assert.Equal(t, email("john@example.com"), *readMail.GetValue())
+	assert.True(t, readSmall.IsNull())
+}
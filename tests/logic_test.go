@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sqlTrue     = nullable.FromValue(true)
+	sqlFalse    = nullable.FromValue(false)
+	sqlUnknown  = nullable.Null[bool]()
+	truthValues = []nullable.Of[bool]{sqlTrue, sqlFalse, sqlUnknown}
+)
+
+// valueName returns the value of v for the names of the subtests, NULL if it is null.
+func valueName[T any](v nullable.Of[T]) string {
+	if v.IsNull() {
+		return "NULL"
+	}
+
+	return fmt.Sprint(*v.GetValue())
+}
+
+func TestThreeValuedLogic(t *testing.T) {
+	t.Run("And", func(t *testing.T) {
+		tests := []struct{ a, b, want nullable.Of[bool] }{
+			{sqlTrue, sqlTrue, sqlTrue},
+			{sqlTrue, sqlFalse, sqlFalse},
+			{sqlTrue, sqlUnknown, sqlUnknown},
+			{sqlFalse, sqlTrue, sqlFalse},
+			{sqlFalse, sqlFalse, sqlFalse},
+			{sqlFalse, sqlUnknown, sqlFalse},
+			{sqlUnknown, sqlTrue, sqlUnknown},
+			{sqlUnknown, sqlFalse, sqlFalse},
+			{sqlUnknown, sqlUnknown, sqlUnknown},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, nullable.And(tt.a, tt.b), "%s AND %s", valueName(tt.a), valueName(tt.b))
+		}
+	})
+
+	t.Run("Or", func(t *testing.T) {
+		tests := []struct{ a, b, want nullable.Of[bool] }{
+			{sqlTrue, sqlTrue, sqlTrue},
+			{sqlTrue, sqlFalse, sqlTrue},
+			{sqlTrue, sqlUnknown, sqlTrue},
+			{sqlFalse, sqlTrue, sqlTrue},
+			{sqlFalse, sqlFalse, sqlFalse},
+			{sqlFalse, sqlUnknown, sqlUnknown},
+			{sqlUnknown, sqlTrue, sqlTrue},
+			{sqlUnknown, sqlFalse, sqlUnknown},
+			{sqlUnknown, sqlUnknown, sqlUnknown},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, nullable.Or(tt.a, tt.b), "%s OR %s", valueName(tt.a), valueName(tt.b))
+		}
+	})
+
+	t.Run("Xor", func(t *testing.T) {
+		tests := []struct{ a, b, want nullable.Of[bool] }{
+			{sqlTrue, sqlTrue, sqlFalse},
+			{sqlTrue, sqlFalse, sqlTrue},
+			{sqlTrue, sqlUnknown, sqlUnknown},
+			{sqlFalse, sqlTrue, sqlTrue},
+			{sqlFalse, sqlFalse, sqlFalse},
+			{sqlFalse, sqlUnknown, sqlUnknown},
+			{sqlUnknown, sqlTrue, sqlUnknown},
+			{sqlUnknown, sqlFalse, sqlUnknown},
+			{sqlUnknown, sqlUnknown, sqlUnknown},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, nullable.Xor(tt.a, tt.b), "%s XOR %s", valueName(tt.a), valueName(tt.b))
+		}
+	})
+
+	t.Run("Not", func(t *testing.T) {
+		assert.Equal(t, sqlFalse, nullable.Not(sqlTrue))
+		assert.Equal(t, sqlTrue, nullable.Not(sqlFalse))
+		assert.Equal(t, sqlUnknown, nullable.Not(sqlUnknown))
+	})
+
+	t.Run("IsTrue", func(t *testing.T) {
+		assert.True(t, nullable.IsTrue(sqlTrue))
+		assert.False(t, nullable.IsTrue(sqlFalse))
+		assert.False(t, nullable.IsTrue(sqlUnknown))
+	})
+
+	t.Run("IsNotFalse", func(t *testing.T) {
+		assert.True(t, nullable.IsNotFalse(sqlTrue))
+		assert.False(t, nullable.IsNotFalse(sqlFalse))
+		assert.True(t, nullable.IsNotFalse(sqlUnknown))
+	})
+}
+
+// TestThreeValuedLogicSQLite checks the results against the SQL evaluation.
+func TestThreeValuedLogicSQLite(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	for _, a := range truthValues {
+		for _, b := range truthValues {
+			var and, or, xor, not nullable.Of[bool]
+			var isTrue, isNotFalse bool
+
+			err := db.QueryRow("SELECT ?1 AND ?2, ?1 OR ?2, ?1 <> ?2, NOT ?1, ?1 IS TRUE, ?1 IS NOT FALSE", &a, &b).
+				Scan(&and, &or, &xor, &not, &isTrue, &isNotFalse)
+			require.NoError(t, err)
+
+			name := valueName(a) + " " + valueName(b)
+			assert.Equal(t, and, nullable.And(a, b), name)
+			assert.Equal(t, or, nullable.Or(a, b), name)
+			assert.Equal(t, xor, nullable.Xor(a, b), name)
+			assert.Equal(t, not, nullable.Not(a), name)
+			assert.Equal(t, isTrue, nullable.IsTrue(a), name)
+			assert.Equal(t, isNotFalse, nullable.IsNotFalse(a), name)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NetTest represents the network address nullable types
+type NetTest struct {
+	ID      int64                         `db:"id"`
+	InetVal nullable.Of[netip.Addr]       `db:"inet_val"`
+	CidrVal nullable.Of[netip.Prefix]     `db:"cidr_val"`
+	MacVal  nullable.Of[net.HardwareAddr] `db:"mac_val"`
+}
+
+func TestScan_NetworkTypes(t *testing.T) {
+	t.Run("inet IPv4", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		require.NoError(t, n.Scan("192.168.0.1"))
+		assert.Equal(t, netip.MustParseAddr("192.168.0.1"), *n.GetValue())
+	})
+
+	t.Run("inet IPv6 from bytes", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		require.NoError(t, n.Scan([]byte("2001:db8::1")))
+		assert.Equal(t, netip.MustParseAddr("2001:db8::1"), *n.GetValue())
+	})
+
+	t.Run("inet with host netmask", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		require.NoError(t, n.Scan("10.0.0.1/32"))
+		assert.Equal(t, netip.MustParseAddr("10.0.0.1"), *n.GetValue())
+	})
+
+	t.Run("inet with network netmask into Addr", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		err := n.Scan("10.0.0.1/24")
+		var parseErr *nullable.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "netip.Addr", parseErr.Type)
+	})
+
+	t.Run("invalid inet", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		err := n.Scan("not an ip")
+		var parseErr *nullable.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "not an ip", parseErr.Input)
+	})
+
+	t.Run("null inet", func(t *testing.T) {
+		n := nullable.FromValue(netip.MustParseAddr("10.0.0.1"))
+		require.NoError(t, n.Scan(nil))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("cidr", func(t *testing.T) {
+		var n nullable.Of[netip.Prefix]
+		require.NoError(t, n.Scan("10.1.0.0/16"))
+		assert.Equal(t, netip.MustParsePrefix("10.1.0.0/16"), *n.GetValue())
+	})
+
+	t.Run("inet host into Prefix", func(t *testing.T) {
+		var n nullable.Of[netip.Prefix]
+		require.NoError(t, n.Scan("10.1.2.3"))
+		assert.Equal(t, netip.MustParsePrefix("10.1.2.3/32"), *n.GetValue())
+	})
+
+	t.Run("invalid cidr", func(t *testing.T) {
+		var n nullable.Of[netip.Prefix]
+		err := n.Scan("10.1.0.0/99")
+		var parseErr *nullable.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "netip.Prefix", parseErr.Type)
+	})
+
+	t.Run("macaddr", func(t *testing.T) {
+		var n nullable.Of[net.HardwareAddr]
+		require.NoError(t, n.Scan("08:00:2b:01:02:03"))
+		assert.Equal(t, "08:00:2b:01:02:03", n.GetValue().String())
+	})
+
+	t.Run("invalid macaddr", func(t *testing.T) {
+		var n nullable.Of[net.HardwareAddr]
+		err := n.Scan("08:00:2b")
+		var parseErr *nullable.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "net.HardwareAddr", parseErr.Type)
+	})
+}
+
+func TestValue_NetworkTypes(t *testing.T) {
+	t.Run("inet", func(t *testing.T) {
+		v, err := nullable.FromValue(netip.MustParseAddr("2001:0db8:0000::0001")).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "2001:db8::1", v)
+	})
+
+	t.Run("cidr", func(t *testing.T) {
+		v, err := nullable.FromValue(netip.MustParsePrefix("10.1.0.0/16")).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "10.1.0.0/16", v)
+	})
+
+	t.Run("macaddr", func(t *testing.T) {
+		hw, err := net.ParseMAC("08-00-2B-01-02-03")
+		require.NoError(t, err)
+		v, err := nullable.FromValue(hw).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "08:00:2b:01:02:03", v)
+	})
+
+	t.Run("zero Addr", func(t *testing.T) {
+		_, err := nullable.FromValue(netip.Addr{}).Value()
+		assert.Error(t, err)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		v, err := nullable.Null[netip.Addr]().Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+}
+
+func TestMarshalUnmarshal_NetworkTypes(t *testing.T) {
+	t.Run("inet round trip", func(t *testing.T) {
+		data, err := json.Marshal(nullable.FromValue(netip.MustParseAddr("10.0.0.1")))
+		require.NoError(t, err)
+		assert.Equal(t, `"10.0.0.1"`, string(data))
+
+		var n nullable.Of[netip.Addr]
+		require.NoError(t, json.Unmarshal(data, &n))
+		assert.Equal(t, netip.MustParseAddr("10.0.0.1"), *n.GetValue())
+	})
+
+	t.Run("cidr round trip", func(t *testing.T) {
+		data, err := json.Marshal(nullable.FromValue(netip.MustParsePrefix("10.1.0.0/16")))
+		require.NoError(t, err)
+		assert.Equal(t, `"10.1.0.0/16"`, string(data))
+
+		var n nullable.Of[netip.Prefix]
+		require.NoError(t, json.Unmarshal(data, &n))
+		assert.Equal(t, netip.MustParsePrefix("10.1.0.0/16"), *n.GetValue())
+	})
+
+	t.Run("macaddr is a string", func(t *testing.T) {
+		hw, err := net.ParseMAC("08:00:2b:01:02:03")
+		require.NoError(t, err)
+		data, err := json.Marshal(nullable.FromValue(hw))
+		require.NoError(t, err)
+		assert.Equal(t, `"08:00:2b:01:02:03"`, string(data))
+
+		var n nullable.Of[net.HardwareAddr]
+		require.NoError(t, json.Unmarshal(data, &n))
+		assert.Equal(t, hw, *n.GetValue())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		require.NoError(t, json.Unmarshal([]byte("null"), &n))
+		assert.True(t, n.IsNull())
+	})
+
+	t.Run("invalid inet", func(t *testing.T) {
+		var n nullable.Of[netip.Addr]
+		err := json.Unmarshal([]byte(`"999.0.0.1"`), &n)
+		var parseErr *nullable.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+	})
+
+	t.Run("non string", func(t *testing.T) {
+		var n nullable.Of[net.HardwareAddr]
+		assert.Error(t, json.Unmarshal([]byte(`42`), &n))
+	})
+}
+
+func TestNetworkTypes(t *testing.T) {
+	db := getDB(t)
+	cleanupTables(t, db, "net_test")
+
+	hw, err := net.ParseMAC("08:00:2b:01:02:03")
+	require.NoError(t, err)
+
+	netTest := NetTest{
+		InetVal: nullable.FromValue(netip.MustParseAddr("2001:db8::1")),
+		CidrVal: nullable.FromValue(netip.MustParsePrefix("10.1.0.0/16")),
+		MacVal:  nullable.FromValue(hw),
+	}
+
+	var insertedID int64
+	t.Run("Writing data into database", func(t *testing.T) {
+		err := db.QueryRow(
+			"INSERT INTO net_test (inet_val, cidr_val, mac_val) VALUES ($1, $2, $3) RETURNING id",
+			&netTest.InetVal, &netTest.CidrVal, &netTest.MacVal,
+		).Scan(&insertedID)
+		require.NoError(t, err, "Insert network types failed")
+	})
+
+	var readTest NetTest
+	t.Run("Reading data from database", func(t *testing.T) {
+		err := db.QueryRow(
+			"SELECT id, inet_val, cidr_val, mac_val FROM net_test WHERE id = $1",
+			insertedID,
+		).Scan(&readTest.ID, &readTest.InetVal, &readTest.CidrVal, &readTest.MacVal)
+		require.NoError(t, err, "Read network types failed")
+	})
+
+	t.Run("data maching read <-> write", func(t *testing.T) {
+		assert.Equal(t, *netTest.InetVal.GetValue(), *readTest.InetVal.GetValue())
+		assert.Equal(t, *netTest.CidrVal.GetValue(), *readTest.CidrVal.GetValue())
+		assert.Equal(t, hw, *readTest.MacVal.GetValue())
+	})
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullabletest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeDriverQuery(t *testing.T) {
+	fake := nullabletest.New()
+	db := fake.DB()
+	t.Cleanup(func() { db.Close() })
+
+	id := uuid.New()
+
+	// Values as returned by the MySQL text protocol or SQLite drivers
+	fake.AddRows("SELECT id, name, active, date_to FROM test", []string{"id", "name", "active", "date_to"},
+		[]any{[]byte(id.String()), []byte("Alice"), int64(1), []byte("2024-02-29 13:45:06")},
+		[]any{id[:], nil, int64(0), nil},
+	)
+
+	rows, err := db.Query(`SELECT id, name, active, date_to
+		FROM test`)
+	require.NoError(t, err, "Query failed")
+
+	defer rows.Close()
+
+	type row struct {
+		ID     nullable.Of[uuid.UUID]
+		Name   nullable.Of[string]
+		Active nullable.Of[bool]
+		DateTo nullable.Of[time.Time]
+	}
+
+	var read []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(&r.ID, &r.Name, &r.Active, &r.DateTo), "Scan failed")
+		read = append(read, r)
+	}
+
+	require.NoError(t, rows.Err())
+	require.Len(t, read, 2)
+
+	assert.Equal(t, id, *read[0].ID.GetValue())
+	assert.Equal(t, "Alice", *read[0].Name.GetValue())
+	assert.True(t, *read[0].Active.GetValue())
+	assert.Equal(t, time.Date(2024, time.February, 29, 13, 45, 6, 0, time.UTC), *read[0].DateTo.GetValue())
+
+	assert.Equal(t, id, *read[1].ID.GetValue())
+	assert.True(t, read[1].Name.IsNull())
+	assert.False(t, *read[1].Active.GetValue())
+	assert.True(t, read[1].DateTo.IsNull())
+
+	t.Run("Scan error", func(t *testing.T) {
+		fake.AddRows("SELECT active FROM test", []string{"active"}, []any{"maybe"})
+
+		var active nullable.Of[bool]
+		err := db.QueryRow("SELECT active FROM test").Scan(&active)
+		assert.Error(t, err)
+	})
+
+	t.Run("Undeclared query", func(t *testing.T) {
+		_, err := db.Query("SELECT 1")
+		assert.ErrorContains(t, err, "no rows declared")
+	})
+}
+
+func TestFakeDriverExec(t *testing.T) {
+	fake := nullabletest.New()
+	db := fake.DB()
+	t.Cleanup(func() { db.Close() })
+
+	query := "INSERT INTO test (name, int16_val, uuid_val, duration_val, data) VALUES ($1, $2, $3, $4, $5)"
+	id := uuid.New()
+
+	result, err := db.Exec(query,
+		nullable.FromValue("Alice"),
+		nullable.FromValue(int16(16)),
+		nullable.FromValue(id),
+		nullable.FromValue(90*time.Minute),
+		nullable.Null[nullable.JSON](),
+	)
+	require.NoError(t, err, "Exec failed")
+
+	affected, err := result.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	_, err = db.Exec("DELETE FROM test")
+	require.NoError(t, err, "Exec failed")
+
+	execs := fake.Execs()
+	require.Len(t, execs, 2)
+
+	assert.Equal(t, query, execs[0].Query)
+	assert.Equal(t, []driver.Value{"Alice", int64(16), id.String(), "1:30:00", nil}, execs[0].Args)
+
+	assert.Equal(t, "DELETE FROM test", execs[1].Query)
+	assert.Empty(t, execs[1].Args)
+
+	t.Run("Reset", func(t *testing.T) {
+		fake.Reset()
+		assert.Empty(t, fake.Execs())
+	})
+}
+
+func TestFakeDriverAddRowsPanics(t *testing.T) {
+	fake := nullabletest.New()
+
+	assert.Panics(t, func() {
+		fake.AddRows("SELECT a, b FROM test", []string{"a", "b"}, []any{1})
+	})
+}
@@ -0,0 +1,108 @@
+package tests
+
+// nullgen is run from the tools module, which depends on golang.org/x/tools
+//go:generate go run -C ../tools ./cmd/nullgen -type NullAmount -elem amount -output ../tests/nullamount_nullgen_test.go
+//go:generate go run -C ../tools ./cmd/nullgen -type NullUUID -elem github.com/google/uuid.UUID -output ../tests/nulluuid_nullgen_test.go
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullgenGenerated(t *testing.T) {
+	t.Run("Conversions", func(t *testing.T) {
+		n := NullAmountFrom(nullable.FromValue(amount(42)))
+		assert.False(t, n.IsNull())
+		assert.Equal(t, amount(42), *n.GetValue())
+		assert.Equal(t, nullable.FromValue(amount(42)), n.Of())
+
+		null := NullAmountFrom(nullable.Null[amount]())
+		assert.True(t, null.IsNull())
+		assert.Nil(t, null.GetValue())
+		assert.Equal(t, nullable.Null[amount](), null.Of())
+
+		var zero NullAmount
+		assert.True(t, zero.IsNull(), "the zero value should be null")
+
+		var ptr *NullAmount
+		assert.True(t, ptr.IsNull(), "a nil pointer should be null")
+		assert.Nil(t, ptr.GetValue())
+	})
+
+	t.Run("Setters", func(t *testing.T) {
+		var n NullAmount
+
+		n.SetValue(7)
+		assert.Equal(t, amount(7), *n.GetValue())
+
+		n.SetNull()
+		assert.True(t, n.IsNull())
+
+		v := amount(8)
+		n.SetValueP(&v)
+		assert.Equal(t, amount(8), *n.GetValue())
+
+		n.SetValueP(nil)
+		assert.True(t, n.IsNull())
+
+		var ptr *NullAmount
+		assert.NotPanics(t, func() {
+			ptr.SetValue(7)
+			ptr.SetValueP(&v)
+			ptr.SetNull()
+		}, "the setters of a nil pointer should do nothing")
+		assert.True(t, ptr.IsNull())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		type payment struct {
+			Amount NullAmount `json:"amount"`
+			ID     NullUUID   `json:"id"`
+		}
+
+		id := uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+
+		var p payment
+		p.Amount.SetValue(12)
+		p.ID.SetValue(id)
+
+		data, err := json.Marshal(p)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"amount":12,"id":"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}`, string(data))
+
+		var decoded payment
+		require.NoError(t, json.Unmarshal([]byte(`{"amount":null,"id":"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}`), &decoded))
+		assert.True(t, decoded.Amount.IsNull())
+		assert.Equal(t, id, *decoded.ID.GetValue())
+	})
+
+	t.Run("Database", func(t *testing.T) {
+		var _ driver.Valuer = NullAmount{}
+
+		db := getSQLiteDB(t)
+
+		var n NullAmount
+		var id NullUUID
+		require.NoError(t, db.QueryRow("SELECT 42, 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11'").Scan(&n, &id))
+		assert.Equal(t, amount(42), *n.GetValue())
+		assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", id.GetValue().String())
+
+		var read NullAmount
+		require.NoError(t, db.QueryRow("SELECT ?", n).Scan(&read))
+		assert.Equal(t, n.Of(), read.Of())
+
+		var null NullAmount
+		require.NoError(t, db.QueryRow("SELECT ?", null).Scan(&read))
+		assert.True(t, read.IsNull())
+
+		v, err := null.Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,227 @@
+package tests
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ pgx.CopyFromSource = (*nullsql.CopySource)(nil)
+
+type copyRow struct {
+	ID    int64                  `db:"id"`
+	Name  nullable.Of[string]    `db:"name"`
+	Score nullable.Of[float64]   `db:"score"`
+	Data  []byte                 `db:"data"`
+	At    nullable.Of[time.Time] `db:"at"`
+	Skip  string                 `db:"-"`
+}
+
+func TestCopy(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC)
+	rows := []copyRow{
+		{
+			ID: 1, Name: nullable.FromValue("a\tb\\c\nd"), Score: nullable.FromValue(1.5),
+			Data: []byte{0xde, 0xad}, At: nullable.FromValue(at),
+		},
+		{ID: 2, Name: nullable.FromValue(`\N`), Score: nullable.FromValue(math.Inf(-1))},
+		{ID: 3, Name: nullable.FromValue(`say "hi", bye`)},
+		{ID: 4, Name: nullable.FromValue("")},
+	}
+
+	t.Run("Columns and statement", func(t *testing.T) {
+		src, err := nullsql.CopyFrom(rows)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "name", "score", "data", "at"}, src.Columns())
+		assert.Equal(t, "COPY t (id, name, score, data, at) FROM STDIN", src.Statement("t", nullsql.CopyText))
+		assert.Equal(t, `COPY t (id, name, score, data, at) FROM STDIN WITH (FORMAT csv, NULL '\N')`,
+			src.Statement("t", nullsql.CopyCSV))
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		src, err := nullsql.CopyFrom(rows[2:3])
+		require.NoError(t, err)
+		require.True(t, src.Next())
+
+		values, err := src.Values()
+		require.NoError(t, err)
+		assert.Equal(t, []any{int64(3), `say "hi", bye`, nil, nil, nil}, values)
+		assert.False(t, src.Next())
+		assert.NoError(t, src.Err())
+	})
+
+	t.Run("Text format", func(t *testing.T) {
+		src, err := nullsql.CopyFrom(rows)
+		require.NoError(t, err)
+
+		var b strings.Builder
+		require.NoError(t, nullsql.WriteCopy(&b, src, nullsql.CopyText))
+		assert.Equal(t, "1\ta\\tb\\\\c\\nd\t1.5\t\\\\xdead\t2024-03-01 12:30:00.0000005Z\n"+
+			"2\t\\\\N\t-Infinity\t\\N\t\\N\n"+
+			"3\tsay \"hi\", bye\t\\N\t\\N\t\\N\n"+
+			"4\t\t\\N\t\\N\t\\N\n", b.String())
+	})
+
+	t.Run("CSV format", func(t *testing.T) {
+		src, err := nullsql.CopyFrom(rows)
+		require.NoError(t, err)
+
+		var b strings.Builder
+		require.NoError(t, nullsql.WriteCopy(&b, src, nullsql.CopyCSV))
+		assert.Equal(t, "1,\"a\tb\\c\nd\",1.5,\\xdead,2024-03-01 12:30:00.0000005Z\n"+
+			"2,\"\\N\",-Infinity,\\N,\\N\n"+
+			"3,\"say \"\"hi\"\", bye\",\\N,\\N,\\N\n"+
+			"4,\"\",\\N,\\N,\\N\n", b.String())
+	})
+
+	t.Run("End-of-data marker", func(t *testing.T) {
+		src, err := nullsql.CopyFrom([]copyRow{{ID: 5, Name: nullable.FromValue(`\.`)}})
+		require.NoError(t, err)
+
+		var b strings.Builder
+		require.NoError(t, nullsql.WriteCopy(&b, src, nullsql.CopyCSV))
+		assert.Equal(t, "5,\"\\.\",\\N,\\N,\\N\n", b.String(), "the marker should be quoted")
+	})
+
+	t.Run("Sequence of pointers", func(t *testing.T) {
+		src, err := nullsql.CopyFromSeq(slices.Values([]*copyRow{&rows[2], &rows[3]}))
+		require.NoError(t, err)
+
+		var b strings.Builder
+		require.NoError(t, nullsql.WriteCopy(&b, src, nullsql.CopyText))
+		assert.Equal(t, "3\tsay \"hi\", bye\t\\N\t\\N\t\\N\n4\t\t\\N\t\\N\t\\N\n", b.String())
+	})
+
+	t.Run("Sequence closed before its end", func(t *testing.T) {
+		stopped := false
+		seq := func(yield func(copyRow) bool) {
+			defer func() { stopped = true }()
+
+			for _, row := range rows {
+				if !yield(row) {
+					return
+				}
+			}
+		}
+
+		src, err := nullsql.CopyFromSeq(seq)
+		require.NoError(t, err)
+		require.True(t, src.Next())
+		src.Close()
+		assert.True(t, stopped)
+	})
+}
+
+func TestCopyErrors(t *testing.T) {
+	t.Run("Not a slice", func(t *testing.T) {
+		_, err := nullsql.CopyFrom(copyRow{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Not structs", func(t *testing.T) {
+		_, err := nullsql.CopyFromSeq(slices.Values([]int{1}))
+		assert.Error(t, err)
+	})
+
+	t.Run("No columns", func(t *testing.T) {
+		_, err := nullsql.CopyFrom([]struct{ A int }{{1}})
+		assert.ErrorIs(t, err, nullsql.ErrNoColumns)
+	})
+
+	t.Run("Nil row", func(t *testing.T) {
+		src, err := nullsql.CopyFrom([]*copyRow{{ID: 1}, nil})
+		require.NoError(t, err)
+
+		var b strings.Builder
+		err = nullsql.WriteCopy(&b, src, nullsql.CopyText)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "row 1")
+	})
+
+	t.Run("Valuer error", func(t *testing.T) {
+		type row struct {
+			V failingValuer `db:"v"`
+		}
+
+		src, err := nullsql.CopyFrom([]row{{}})
+		require.NoError(t, err)
+		assert.False(t, src.Next())
+		assert.ErrorIs(t, src.Err(), errValuer)
+	})
+}
+
+var errValuer = errors.New("valuer failure")
+
+// failingValuer is a driver.Valuer which always fails.
+type failingValuer struct{}
+
+func (failingValuer) Value() (driver.Value, error) {
+	return nil, errValuer
+}
+
+func TestCopyFromExec(t *testing.T) {
+	db := getDB(t)
+	cleanupTables(t, db, "type_test")
+
+	rows := []typeTestRow{
+		{StringVal: nullable.FromValue("tab\there"), IntVal: nullable.FromValue(1)},
+		{StringVal: nullable.FromValue(`\N`)},
+		{IntVal: nullable.FromValue(3)},
+	}
+
+	type copyTestRow struct {
+		StringVal nullable.Of[string] `db:"string_val"`
+		IntVal    nullable.Of[int]    `db:"int_val"`
+	}
+
+	copyRows := make([]copyTestRow, len(rows))
+	for i, row := range rows {
+		copyRows[i] = copyTestRow{StringVal: row.StringVal, IntVal: row.IntVal}
+	}
+
+	src, err := nullsql.CopyFrom(copyRows)
+	require.NoError(t, err)
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn any) error {
+		pgConn := driverConn.(*stdlib.Conn).Conn()
+		count, err := pgConn.CopyFrom(context.Background(), pgx.Identifier{"type_test"}, src.Columns(), src)
+		assert.Equal(t, int64(len(rows)), count)
+
+		return err
+	})
+	require.NoError(t, err)
+
+	result, err := db.Query("SELECT string_val, int_val FROM type_test ORDER BY id")
+	require.NoError(t, err)
+
+	defer result.Close()
+
+	var got []typeTestRow
+
+	for result.Next() {
+		var row typeTestRow
+		require.NoError(t, result.Scan(&row.StringVal, &row.IntVal))
+
+		got = append(got, row)
+	}
+
+	require.NoError(t, result.Err())
+	assert.Equal(t, rows, got)
+}
@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"database/sql"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update is set to update the golden files instead of checking them.
+var update = os.Getenv("UPDATE_GOLDEN") != ""
+
+// checkGolden compares got to the content of the file golden, or writes it with UPDATE_GOLDEN=1.
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+
+	if update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "missing golden file, run with UPDATE_GOLDEN=1")
+	assert.Equal(t, string(want), string(got), "differs from %s", golden)
+}
+
+type ddlAudit struct {
+	CreatedAt time.Time              `db:"created_at" ddl:"default:CURRENT_TIMESTAMP"`
+	DeletedAt nullable.Of[time.Time] `db:"deleted_at"`
+}
+
+type ddlOwner struct {
+	OwnerID int64 `db:"owner_id"`
+}
+
+type ddlRow struct {
+	ID       userID                          `db:"id" ddl:"postgres_type:BIGSERIAL;constraints:PRIMARY KEY"`
+	Name     string                          `db:"name" ddl:"type:VARCHAR(255)"`
+	Nickname *string                         `db:"nickname"`
+	Bool     nullable.Of[bool]               `db:"bool_val"`
+	Int      nullable.Of[int]                `db:"int_val"`
+	Int16    nullable.Of[int16]              `db:"int16_val"`
+	Int32    nullable.Of[int32]              `db:"int32_val"`
+	Float    nullable.Of[float64]            `db:"float_val"`
+	UUID     nullable.Of[uuid.UUID]          `db:"uuid_val"`
+	JSON     nullable.Of[nullable.JSON]      `db:"json_val"`
+	Struct   nullable.Of[map[string]any]     `db:"struct_val"`
+	Date     nullable.Of[nullable.Date]      `db:"date_val"`
+	Time     nullable.Of[nullable.TimeOfDay] `db:"time_val"`
+	Duration nullable.Of[time.Duration]      `db:"duration_val"`
+	Addr     nullable.Of[netip.Addr]         `db:"addr_val"`
+	Prefix   nullable.Of[netip.Prefix]       `db:"prefix_val"`
+	MAC      nullable.Of[net.HardwareAddr]   `db:"mac_val"`
+	Status   nullable.Of[status]             `db:"status_val"`
+	Amount   NullAmount                      `db:"amount"`
+	Token    NullUUID                        `db:"token"`
+	Price    nullable.Of[float64]            `db:"price" ddl:"type:NUMERIC(12,2);sqlite_type:REAL"`
+	Data     []byte                          `db:"data"`
+	Count    int                             `db:"count" ddl:"default:0;constraints:CHECK (count >= 0)"`
+	Ignored  nullable.Of[string]             `db:"-"`
+	Untagged nullable.Of[string]
+	ddlAudit
+	*ddlOwner
+}
+
+func TestCreateTable(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts nullsql.CreateTableOptions
+	}{
+		{"postgres", nullsql.CreateTableOptions{}},
+		{"sqlite", nullsql.CreateTableOptions{Dialect: nullsql.SQLite, IfNotExists: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ddl, err := nullsql.CreateTable("ddl_test", (*ddlRow)(nil), tc.opts)
+			require.NoError(t, err)
+			checkGolden(t, filepath.Join("testdata", "nullsql", tc.name+".sql.golden"), []byte(ddl+"\n"))
+		})
+	}
+
+	t.Run("Byte", func(t *testing.T) {
+		ddl, err := nullsql.CreateTable("t", struct {
+			B nullable.Of[byte] `db:"b"`
+		}{}, nullsql.CreateTableOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "CREATE TABLE t (\n    b SMALLINT\n);", ddl)
+	})
+
+	t.Run("Struct value", func(t *testing.T) {
+		ddl, err := nullsql.CreateTable("t", TypeTest{}, nullsql.CreateTableOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, ddl, "    id BIGINT NOT NULL,\n    string_val TEXT,\n")
+		assert.Contains(t, ddl, "    json_val JSONB\n);")
+	})
+}
+
+func TestCreateTableErrors(t *testing.T) {
+	t.Run("Not a struct", func(t *testing.T) {
+		_, err := nullsql.CreateTable("t", 1, nullsql.CreateTableOptions{})
+		assert.Error(t, err)
+
+		_, err = nullsql.CreateTable("t", nil, nullsql.CreateTableOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("No columns", func(t *testing.T) {
+		_, err := nullsql.CreateTable("t", struct{ A int }{}, nullsql.CreateTableOptions{})
+		assert.ErrorIs(t, err, nullsql.ErrNoColumns)
+	})
+
+	t.Run("Custom valuer without type", func(t *testing.T) {
+		_, err := nullsql.CreateTable("t", struct {
+			V failingValuer `db:"v"`
+		}{}, nullsql.CreateTableOptions{})
+		assert.ErrorIs(t, err, nullsql.ErrNoSQLType)
+	})
+
+	t.Run("Struct without type", func(t *testing.T) {
+		_, err := nullsql.CreateTable("t", struct {
+			V struct{ A int } `db:"v"`
+		}{}, nullsql.CreateTableOptions{})
+		assert.ErrorIs(t, err, nullsql.ErrNoSQLType)
+	})
+
+	t.Run("Invalid ddl tag", func(t *testing.T) {
+		for _, v := range []any{
+			struct {
+				V int `db:"v" ddl:"size:10"`
+			}{},
+			struct {
+				V int `db:"v" ddl:"type"`
+			}{},
+			struct {
+				V int `db:"v" ddl:"type:INTEGER;default:"`
+			}{},
+		} {
+			_, err := nullsql.CreateTable("t", v, nullsql.CreateTableOptions{})
+			assert.Error(t, err, "%T", v)
+		}
+	})
+}
+
+func TestCreateTableExec(t *testing.T) {
+	type row struct {
+		ID     int64               `db:"id" ddl:"sqlite_type:INTEGER;postgres_type:BIGSERIAL;constraints:PRIMARY KEY"`
+		Name   nullable.Of[string] `db:"name"`
+		Status nullable.Of[status] `db:"status"`
+		Count  int                 `db:"count" ddl:"default:7"`
+	}
+
+	rows := []row{
+		{Name: nullable.FromValue("a"), Status: nullable.FromValue(statusActive)},
+		{},
+	}
+
+	// run creates the table, inserts the rows without their ids and counts, and reads them back.
+	run := func(t *testing.T, db *sql.DB, dialect nullsql.Dialect, placeholder nullsql.Placeholder) {
+		t.Helper()
+
+		ddl, err := nullsql.CreateTable("ddl_exec_test", row{}, nullsql.CreateTableOptions{Dialect: dialect})
+		require.NoError(t, err)
+
+		_, err = db.Exec(ddl)
+		require.NoError(t, err, ddl)
+
+		t.Cleanup(func() { db.Exec("DROP TABLE ddl_exec_test") })
+
+		statements, err := nullsql.Insert("ddl_exec_test", rows, nullsql.InsertOptions{
+			Placeholder: placeholder, Omit: []string{"id", "count"},
+		})
+		require.NoError(t, err)
+
+		for _, s := range statements {
+			_, err = db.Exec(s.Query, s.Args...)
+			require.NoError(t, err, s.Query)
+		}
+
+		result, err := db.Query("SELECT name, status, count FROM ddl_exec_test ORDER BY id")
+		require.NoError(t, err)
+
+		defer result.Close()
+
+		var got []row
+
+		for result.Next() {
+			var r row
+			require.NoError(t, result.Scan(&r.Name, &r.Status, &r.Count))
+
+			got = append(got, r)
+		}
+
+		require.NoError(t, result.Err())
+		require.Len(t, got, len(rows))
+
+		for i, r := range got {
+			assert.Equal(t, rows[i].Name, r.Name)
+			assert.Equal(t, rows[i].Status, r.Status)
+			assert.Equal(t, 7, r.Count)
+		}
+
+		// The CHECK constraint of the enum rejects the unknown values
+		_, err = db.Exec("INSERT INTO ddl_exec_test (status) VALUES ('unknown')")
+		assert.Error(t, err)
+	}
+
+	t.Run("SQLite", func(t *testing.T) {
+		run(t, getSQLiteDB(t), nullsql.SQLite, nullsql.Question)
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		run(t, getDB(t), nullsql.Postgres, nullsql.Dollar)
+	})
+}
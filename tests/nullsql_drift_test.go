@@ -0,0 +1,250 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// columnTypes returns the column types of the result of the query.
+func columnTypes(t *testing.T, db *sql.DB, query string) []*sql.ColumnType {
+	t.Helper()
+
+	rows, err := db.Query(query)
+	require.NoError(t, err, query)
+
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	require.NoError(t, err)
+
+	return columns
+}
+
+// fakeColumn is a column of the result of a columnsDB query.
+type fakeColumn struct {
+	name, databaseType string
+	nullable           bool
+}
+
+// columnsDB is a database/sql driver whose queries return no rows and the columns,
+// with their nullability if nullability is set.
+type columnsDB struct {
+	columns     []fakeColumn
+	nullability bool
+}
+
+func (c columnsDB) Open(string) (driver.Conn, error)             { return c, nil }
+func (c columnsDB) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c columnsDB) Driver() driver.Driver                        { return c }
+func (c columnsDB) Prepare(string) (driver.Stmt, error)          { return c, nil }
+func (c columnsDB) Close() error                                 { return nil }
+func (c columnsDB) Begin() (driver.Tx, error)                    { return nil, errors.ErrUnsupported }
+func (c columnsDB) NumInput() int                                { return -1 }
+func (c columnsDB) Exec([]driver.Value) (driver.Result, error)   { return nil, errors.ErrUnsupported }
+
+func (c columnsDB) Query([]driver.Value) (driver.Rows, error) {
+	if c.nullability {
+		return nullableColumnsRows{columnsRows{c.columns}}, nil
+	}
+
+	return columnsRows{c.columns}, nil
+}
+
+type columnsRows struct {
+	columns []fakeColumn
+}
+
+func (r columnsRows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		names[i] = c.name
+	}
+
+	return names
+}
+
+func (r columnsRows) Close() error                            { return nil }
+func (r columnsRows) Next([]driver.Value) error               { return io.EOF }
+func (r columnsRows) ColumnTypeDatabaseTypeName(i int) string { return r.columns[i].databaseType }
+
+type nullableColumnsRows struct {
+	columnsRows
+}
+
+func (r nullableColumnsRows) ColumnTypeNullable(i int) (bool, bool) {
+	return r.columns[i].nullable, true
+}
+
+// fakeColumnTypes returns the column types of the result of a columnsDB query.
+func fakeColumnTypes(t *testing.T, db columnsDB) []*sql.ColumnType {
+	t.Helper()
+
+	conn := sql.OpenDB(db)
+	t.Cleanup(func() { conn.Close() })
+
+	return columnTypes(t, conn, "SELECT")
+}
+
+type driftRow struct {
+	ID        int64               `db:"id"`
+	StringVal string              `db:"string_val"`
+	IntVal    nullable.Of[int16]  `db:"int_val"`
+	BoolVal   nullable.Of[int]    `db:"bool_val"`
+	UUIDVal   nullable.Of[string] `db:"uuid_val"`
+	Missing   nullable.Of[string] `db:"missing"`
+}
+
+func TestCheckColumns(t *testing.T) {
+	t.Run("SQLite in sync", func(t *testing.T) {
+		db := getSQLiteDB(t)
+
+		mismatches, checked, err := nullsql.CheckColumns((*TypeTest)(nil), columnTypes(t, db, "SELECT * FROM type_test"),
+			nullsql.CheckOptions{IgnoreNullability: true})
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+		assert.False(t, checked, "the nullability is ignored")
+	})
+
+	t.Run("SQLite drift", func(t *testing.T) {
+		db := getSQLiteDB(t)
+
+		columns := columnTypes(t, db, "SELECT string_val, int_val, bool_val, uuid_val, float_val, 1 + 1 AS n FROM type_test")
+
+		mismatches, _, err := nullsql.CheckColumns(driftRow{}, columns, nullsql.CheckOptions{IgnoreNullability: true})
+		require.NoError(t, err)
+
+		var got []string
+		for _, m := range mismatches {
+			got = append(got, m.String())
+		}
+
+		assert.Equal(t, []string{
+			"column int_val of type INTEGER is incompatible with the field IntVal of type nullable.Of[int16]",
+			"column bool_val of type BOOLEAN is incompatible with the field BoolVal of type nullable.Of[int]",
+			"column float_val is not a column of the struct",
+			"column n is not a column of the struct",
+			"column id of the field ID is not a column of the result",
+			"column missing of the field Missing is not a column of the result",
+		}, got)
+
+		assert.Equal(t, nullsql.ExtraColumn, mismatches[2].Kind)
+		assert.Equal(t, "DOUBLE PRECISION", mismatches[2].DatabaseType)
+		assert.Equal(t, nullsql.MissingColumn, mismatches[4].Kind)
+	})
+
+	t.Run("Named, nullable and JSON types", func(t *testing.T) {
+		db := getSQLiteDB(t)
+
+		type row struct {
+			StringVal nullable.Of[email]          `db:"string_val"`
+			IntVal    *userID                     `db:"int_val"`
+			JSONVal   nullable.Of[map[string]any] `db:"json_val"`
+			TimeVal   NullAmount                  `db:"time_val"`
+		}
+
+		columns := columnTypes(t, db, "SELECT string_val, int_val, json_val, time_val FROM type_test")
+
+		mismatches, _, err := nullsql.CheckColumns(row{}, columns, nullsql.CheckOptions{})
+		require.NoError(t, err)
+		require.Len(t, mismatches, 1)
+		assert.Equal(t, nullsql.IncompatibleType, mismatches[0].Kind)
+		assert.Equal(t, "time_val", mismatches[0].Column)
+	})
+
+	t.Run("Nullable columns", func(t *testing.T) {
+		type row struct {
+			ID       int64               `db:"id"`
+			Name     string              `db:"name"`
+			Nickname nullable.Of[string] `db:"nickname"`
+			Email    *string             `db:"email"`
+		}
+
+		db := columnsDB{columns: []fakeColumn{
+			{name: "id", databaseType: "INT8"},
+			{name: "name", databaseType: "TEXT", nullable: true},
+			{name: "nickname", databaseType: "TEXT", nullable: true},
+			{name: "email", databaseType: "TEXT", nullable: true},
+		}, nullability: true}
+
+		mismatches, checked, err := nullsql.CheckColumns(row{}, fakeColumnTypes(t, db), nullsql.CheckOptions{})
+		require.NoError(t, err)
+		assert.True(t, checked)
+		require.Len(t, mismatches, 1)
+		assert.Equal(t, nullsql.NullableColumn, mismatches[0].Kind)
+		assert.Equal(t, "column name is nullable but the field Name of type string can't be null", mismatches[0].String())
+
+		mismatches, checked, err = nullsql.CheckColumns(row{}, fakeColumnTypes(t, db),
+			nullsql.CheckOptions{IgnoreNullability: true})
+		require.NoError(t, err)
+		assert.False(t, checked)
+		assert.Empty(t, mismatches)
+
+		// The nullability isn't reported by the driver, as with pgx
+		db.nullability = false
+
+		mismatches, checked, err = nullsql.CheckColumns(row{}, fakeColumnTypes(t, db), nullsql.CheckOptions{})
+		require.NoError(t, err)
+		assert.False(t, checked)
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("Compatible types", func(t *testing.T) {
+		for _, tc := range []struct {
+			field        any
+			databaseType string
+			compatible   bool
+		}{
+			{nullable.Of[int16]{}, "INT2", true},
+			{nullable.Of[int16]{}, "INT4", false},
+			{nullable.Of[int32]{}, "SMALLINT", true},
+			{nullable.Of[int64]{}, "INTEGER", true},
+			{nullable.Of[int64]{}, "NUMERIC", false},
+			{nullable.Of[int]{}, "DECIMAL(10,2)", false},
+			{nullable.Of[int]{}, "FLOAT8", false},
+			{nullable.Of[float64]{}, "BIGINT", true},
+			{nullable.Of[byte]{}, "SMALLINT", true},
+			{nullable.Of[byte]{}, "INTEGER", true},
+			{nullable.Of[byte]{}, "TEXT", false},
+			{nullable.Of[float64]{}, "NUMERIC(12,2)", true},
+			{nullable.Of[string]{}, "NUMERIC", true},
+			{nullable.Of[string]{}, "varchar(255)", true},
+			{nullable.Of[bool]{}, "INT4", false},
+		} {
+			typ := reflect.StructOf([]reflect.StructField{{
+				Name: "V", Type: reflect.TypeOf(tc.field), Tag: `db:"v"`,
+			}})
+
+			db := columnsDB{columns: []fakeColumn{{name: "v", databaseType: tc.databaseType}}}
+
+			mismatches, _, err := nullsql.CheckColumns(reflect.New(typ).Interface(), fakeColumnTypes(t, db),
+				nullsql.CheckOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.compatible, len(mismatches) == 0, "%T and %s", tc.field, tc.databaseType)
+		}
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		_, _, err := nullsql.CheckColumns(1, nil, nullsql.CheckOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("PostgreSQL in sync", func(t *testing.T) {
+		db := getDB(t)
+
+		mismatches, checked, err := nullsql.CheckColumns(TypeTest{}, columnTypes(t, db, "SELECT * FROM type_test"),
+			nullsql.CheckOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+		assert.False(t, checked, "pgx doesn't report the nullability")
+	})
+}
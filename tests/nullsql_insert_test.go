@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type insertRow struct {
+	ID      int64               `db:"id"`
+	Name    nullable.Of[string] `db:"name"`
+	Balance nullable.Of[int]    `db:"balance,default"`
+}
+
+// countingValuer counts the calls of its method Value.
+type countingValuer struct {
+	calls *int
+}
+
+func (v countingValuer) Value() (driver.Value, error) {
+	*v.calls++
+
+	return "counted", nil
+}
+
+func TestInsert(t *testing.T) {
+	rows := []insertRow{
+		{ID: 1, Name: nullable.FromValue("a"), Balance: nullable.FromValue(10)},
+		{ID: 2},
+	}
+
+	t.Run("Multi-row with DEFAULT and RETURNING", func(t *testing.T) {
+		statements, err := nullsql.Insert("accounts", rows, nullsql.InsertOptions{
+			Omit: []string{"id"}, Returning: []string{"id"},
+		})
+		require.NoError(t, err)
+
+		want := []nullsql.Statement{{
+			Query: "INSERT INTO accounts (name, balance) VALUES ($1, $2), ($3, DEFAULT) RETURNING id",
+			Args:  []any{"a", int64(10), nil},
+		}}
+		assert.Equal(t, want, statements)
+	})
+
+	t.Run("Default selected by option", func(t *testing.T) {
+		statements, err := nullsql.Insert("accounts", []*insertRow{&rows[1]}, nullsql.InsertOptions{
+			Placeholder: nullsql.Question, Default: []string{"name"},
+		})
+		require.NoError(t, err)
+		require.Len(t, statements, 1)
+		assert.Equal(t, "INSERT INTO accounts (id, name, balance) VALUES (?, DEFAULT, DEFAULT)", statements[0].Query)
+		assert.Equal(t, []any{int64(2)}, statements[0].Args)
+	})
+
+	t.Run("Named placeholders", func(t *testing.T) {
+		statements, err := nullsql.Insert("accounts", rows, nullsql.InsertOptions{Placeholder: nullsql.Named})
+		require.NoError(t, err)
+		require.Len(t, statements, 1)
+		assert.Equal(t, "INSERT INTO accounts (id, name, balance) VALUES "+
+			"(:id_1, :name_1, :balance_1), (:id_2, :name_2, DEFAULT)", statements[0].Query)
+		assert.Equal(t, []any{
+			sql.Named("id_1", int64(1)), sql.Named("name_1", "a"), sql.Named("balance_1", int64(10)),
+			sql.Named("id_2", int64(2)), sql.Named("name_2", nil),
+		}, statements[0].Args)
+	})
+
+	t.Run("Split under the parameter limit", func(t *testing.T) {
+		many := make([]insertRow, 5)
+		for i := range many {
+			many[i] = insertRow{ID: int64(i), Name: nullable.FromValue("x"), Balance: nullable.FromValue(i)}
+		}
+
+		many[1].Balance.SetNull()
+
+		statements, err := nullsql.Insert("accounts", many, nullsql.InsertOptions{MaxParams: 6})
+		require.NoError(t, err)
+		require.Len(t, statements, 3)
+		assert.Equal(t, "INSERT INTO accounts (id, name, balance) VALUES ($1, $2, $3), ($4, $5, DEFAULT)",
+			statements[0].Query)
+		assert.Len(t, statements[1].Args, 6)
+		assert.Equal(t, "INSERT INTO accounts (id, name, balance) VALUES ($1, $2, $3)", statements[2].Query)
+
+		_, err = nullsql.Insert("accounts", many, nullsql.InsertOptions{MaxParams: 2})
+		require.ErrorIs(t, err, nullsql.ErrTooManyParams)
+	})
+
+	t.Run("Value called once", func(t *testing.T) {
+		type counted struct {
+			V countingValuer `db:"v"`
+		}
+
+		calls := 0
+
+		statements, err := nullsql.Insert("t", []counted{{V: countingValuer{calls: &calls}}}, nullsql.InsertOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, []any{"counted"}, statements[0].Args)
+	})
+
+	t.Run("No rows", func(t *testing.T) {
+		statements, err := nullsql.Insert("accounts", []insertRow{}, nullsql.InsertOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, statements)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := nullsql.Insert("accounts", rows[0], nullsql.InsertOptions{})
+		require.Error(t, err)
+
+		_, err = nullsql.Insert("accounts", []int{1}, nullsql.InsertOptions{})
+		require.Error(t, err)
+
+		_, err = nullsql.Insert("accounts", []*insertRow{nil}, nullsql.InsertOptions{})
+		require.Error(t, err)
+
+		_, err = nullsql.Insert("accounts", rows, nullsql.InsertOptions{Omit: []string{"uid"}})
+		require.ErrorIs(t, err, nullsql.ErrUnknownColumn)
+
+		_, err = nullsql.Insert("accounts", rows, nullsql.InsertOptions{Omit: []string{"id", "name", "balance"}})
+		require.ErrorIs(t, err, nullsql.ErrNoColumns)
+	})
+}
+
+// typeTestRow is a row of the table type_test, created by init.sql and init_sqlite.sql.
+type typeTestRow struct {
+	ID        nullable.Of[int64]  `db:"id,default"`
+	StringVal nullable.Of[string] `db:"string_val"`
+	IntVal    nullable.Of[int]    `db:"int_val"`
+}
+
+func TestInsertExec(t *testing.T) {
+	rows := []typeTestRow{
+		{StringVal: nullable.FromValue("first"), IntVal: nullable.FromValue(1)},
+		{StringVal: nullable.FromValue("second")},
+		{IntVal: nullable.FromValue(3)},
+	}
+
+	// run executes the statements inserting the rows, which return their ids, and reads the rows with read.
+	run := func(t *testing.T, db *sql.DB, statements []nullsql.Statement, read string) {
+		t.Helper()
+
+		var ids []int64
+
+		for _, s := range statements {
+			result, err := db.Query(s.Query, s.Args...)
+			require.NoError(t, err, s.Query)
+
+			for result.Next() {
+				var id int64
+				require.NoError(t, result.Scan(&id))
+
+				ids = append(ids, id)
+			}
+
+			require.NoError(t, result.Err())
+			result.Close()
+		}
+
+		require.Len(t, ids, len(rows))
+
+		for i, id := range ids {
+			var row typeTestRow
+			require.NoError(t, db.QueryRow(read, id).Scan(&row.StringVal, &row.IntVal))
+			assert.Equal(t, rows[i].StringVal, row.StringVal)
+			assert.Equal(t, rows[i].IntVal, row.IntVal)
+		}
+	}
+
+	t.Run("SQLite", func(t *testing.T) {
+		db := getSQLiteDB(t)
+
+		for _, placeholder := range []nullsql.Placeholder{nullsql.Question, nullsql.Named} {
+			// SQLite doesn't support DEFAULT in VALUES: the id is omitted
+			statements, err := nullsql.Insert("type_test", rows, nullsql.InsertOptions{
+				Placeholder: placeholder, Omit: []string{"id"}, Returning: []string{"id"}, MaxParams: 4,
+			})
+			require.NoError(t, err)
+			require.Len(t, statements, 2)
+
+			run(t, db, statements, "SELECT string_val, int_val FROM type_test WHERE id = ?")
+		}
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		db := getDB(t)
+		cleanupTables(t, db, "type_test")
+
+		statements, err := nullsql.Insert("type_test", rows, nullsql.InsertOptions{
+			Returning: []string{"id"}, MaxParams: 4,
+		})
+		require.NoError(t, err)
+
+		run(t, db, statements, "SELECT string_val, int_val FROM type_test WHERE id = $1")
+	})
+}
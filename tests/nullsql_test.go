@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userPatch struct {
+	ID    int64               `db:"id"`
+	Name  nullable.Of[string] `db:"name"`
+	Email nullable.Of[string] `db:"email"`
+	Age   nullable.Of[int]    `db:"age"`
+	Note  *string             `db:"note"`
+	Cache string              `db:"-"`
+	Other string
+}
+
+type auditedPatch struct {
+	auditColumns
+	*userPatch
+}
+
+type auditColumns struct {
+	UpdatedBy nullable.Of[string] `db:"updated_by"`
+}
+
+func TestUpdate(t *testing.T) {
+	patch := userPatch{ID: 7, Name: nullable.FromValue("Ada"), Age: nullable.FromValue(36)}
+
+	tests := []struct {
+		name      string
+		v         any
+		opts      nullsql.UpdateOptions
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "All with dollar placeholders",
+			v:         patch,
+			wantQuery: "UPDATE users SET name = $1, email = $2, age = $3, note = $4 WHERE id = $5",
+			wantArgs:  []any{patch.Name, patch.Email, patch.Age, nil, int64(7)},
+		},
+		{
+			name:      "Non-null with question placeholders",
+			v:         &patch,
+			opts:      nullsql.UpdateOptions{Placeholder: nullsql.Question, Include: nullsql.IncludeNonNull},
+			wantQuery: "UPDATE users SET name = ?, age = ? WHERE id = ?",
+			wantArgs:  []any{patch.Name, patch.Age, int64(7)},
+		},
+		{
+			name: "Changed with named placeholders",
+			v:    patch,
+			opts: nullsql.UpdateOptions{
+				Placeholder: nullsql.Named, Include: nullsql.IncludeChanged, Changed: []string{"email", "age"},
+			},
+			wantQuery: "UPDATE users SET email = :email, age = :age WHERE id = :id",
+			wantArgs:  []any{sql.Named("email", patch.Email), sql.Named("age", patch.Age), sql.Named("id", int64(7))},
+		},
+		{
+			name:      "Embedded structs",
+			v:         auditedPatch{auditColumns{UpdatedBy: nullable.FromValue("admin")}, &patch},
+			opts:      nullsql.UpdateOptions{Include: nullsql.IncludeNonNull},
+			wantQuery: "UPDATE users SET updated_by = $1, name = $2, age = $3 WHERE id = $4",
+			wantArgs:  []any{nullable.FromValue("admin"), patch.Name, patch.Age, int64(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := nullsql.Update("users", tt.v, "id", tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUpdateErrors(t *testing.T) {
+	patch := userPatch{ID: 7}
+
+	_, _, err := nullsql.Update("users", patch, "id", nullsql.UpdateOptions{Include: nullsql.IncludeNonNull})
+	require.ErrorIs(t, err, nullsql.ErrNoColumns)
+
+	_, _, err = nullsql.Update("users", patch, "uid", nullsql.UpdateOptions{})
+	require.ErrorIs(t, err, nullsql.ErrUnknownColumn)
+
+	_, _, err = nullsql.Update("users", patch, "id", nullsql.UpdateOptions{
+		Include: nullsql.IncludeChanged, Changed: []string{"mail"},
+	})
+	require.ErrorIs(t, err, nullsql.ErrUnknownColumn)
+
+	_, _, err = nullsql.Update("users", auditedPatch{}, "id", nullsql.UpdateOptions{})
+	require.Error(t, err, "the key of a nil embedded pointer should be null")
+
+	_, _, err = nullsql.Update("users", "patch", "id", nullsql.UpdateOptions{})
+	require.Error(t, err)
+
+	type duplicate struct {
+		userPatch
+		Name nullable.Of[string] `db:"name"`
+	}
+
+	_, _, err = nullsql.Update("users", duplicate{}, "id", nullsql.UpdateOptions{})
+	require.Error(t, err)
+}
+
+// typeTestPatch is a patch of the table type_test, created by init.sql and init_sqlite.sql.
+type typeTestPatch struct {
+	ID        int64               `db:"id"`
+	StringVal nullable.Of[string] `db:"string_val"`
+	IntVal    nullable.Of[int]    `db:"int_val"`
+	BoolVal   nullable.Of[bool]   `db:"bool_val"`
+}
+
+func TestUpdateExec(t *testing.T) {
+	// run inserts a row with insert, updates it and reads it with read, whose parameter is the id.
+	run := func(t *testing.T, db *sql.DB, placeholder nullsql.Placeholder, insert, read string) {
+		t.Helper()
+
+		_, err := db.Exec(insert, "before", 1, true)
+		require.NoError(t, err)
+
+		var id int64
+
+		require.NoError(t, db.QueryRow("SELECT MAX(id) FROM type_test").Scan(&id))
+
+		patch := typeTestPatch{ID: id, StringVal: nullable.FromValue("after")}
+
+		query, args, err := nullsql.Update("type_test", patch, "id", nullsql.UpdateOptions{
+			Placeholder: placeholder, Include: nullsql.IncludeNonNull,
+		})
+		require.NoError(t, err)
+
+		_, err = db.Exec(query, args...)
+		require.NoError(t, err, query)
+
+		query, args, err = nullsql.Update("type_test", patch, "id", nullsql.UpdateOptions{
+			Placeholder: placeholder, Include: nullsql.IncludeChanged, Changed: []string{"bool_val"},
+		})
+		require.NoError(t, err)
+
+		_, err = db.Exec(query, args...)
+		require.NoError(t, err, query)
+
+		var row typeTestPatch
+
+		err = db.QueryRow(read, id).Scan(&row.StringVal, &row.IntVal, &row.BoolVal)
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue("after"), row.StringVal)
+		assert.Equal(t, nullable.FromValue(1), row.IntVal, "the null values should not be set")
+		assert.Equal(t, nullable.Null[bool](), row.BoolVal, "the changed values should be set")
+	}
+
+	t.Run("SQLite", func(t *testing.T) {
+		db := getSQLiteDB(t)
+		insert := "INSERT INTO type_test (string_val, int_val, bool_val) VALUES (?, ?, ?)"
+		read := "SELECT string_val, int_val, bool_val FROM type_test WHERE id = ?"
+
+		t.Run("Question", func(t *testing.T) { run(t, db, nullsql.Question, insert, read) })
+		t.Run("Named", func(t *testing.T) { run(t, db, nullsql.Named, insert, read) })
+	})
+
+	t.Run("PostgreSQL", func(t *testing.T) {
+		db := getDB(t)
+		cleanupTables(t, db, "type_test")
+
+		run(t, db, nullsql.Dollar,
+			"INSERT INTO type_test (string_val, int_val, bool_val) VALUES ($1, $2, $3)",
+			"SELECT string_val, int_val, bool_val FROM type_test WHERE id = $1")
+
+		// PostgreSQL rejects the Named placeholders, pgx ignoring the names of the arguments
+		query, args, err := nullsql.Update("type_test", typeTestPatch{ID: 1, StringVal: nullable.FromValue("a")}, "id",
+			nullsql.UpdateOptions{Placeholder: nullsql.Named, Include: nullsql.IncludeNonNull})
+		require.NoError(t, err)
+
+		_, err = db.Exec(query, args...)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/ovya/nullable"
+	"github.com/ovya/nullable/nullstruct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullStruct(t *testing.T) {
+	t.Run("From", func(t *testing.T) {
+		text, err := nullstruct.From[string](pgtype.Text{String: "a", Valid: true})
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue("a"), text)
+
+		text, err = nullstruct.From[string](pgtype.Text{String: "ignored"})
+		require.NoError(t, err)
+		assert.Equal(t, nullable.Null[string](), text)
+
+		id := uuid.New()
+		uid, err := nullstruct.From[uuid.UUID](pgtype.UUID{Bytes: id, Valid: true})
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(id), uid)
+
+		ts, err := nullstruct.From[time.Time](pgtype.Timestamptz{Time: now, InfinityModifier: pgtype.Finite, Valid: true})
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(now), ts)
+
+		i, err := nullstruct.From[int64](pgtype.Int4{Int32: 32, Valid: true})
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(int64(32)), i, "integers are converted")
+	})
+
+	t.Run("To", func(t *testing.T) {
+		text, err := nullstruct.To[pgtype.Text](nullable.FromValue("a"))
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.Text{String: "a", Valid: true}, text)
+
+		text, err = nullstruct.To[pgtype.Text](nullable.Null[string]())
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.Text{}, text)
+
+		id := uuid.New()
+		uid, err := nullstruct.To[pgtype.UUID](nullable.FromValue(id))
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.UUID{Bytes: id, Valid: true}, uid)
+
+		b, err := nullstruct.To[pgtype.Bool](nullable.FromValue(true))
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.Bool{Bool: true, Valid: true}, b)
+
+		i, err := nullstruct.To[pgtype.Int2](nullable.FromValue(-16))
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.Int2{Int16: -16, Valid: true}, i)
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		_, err := nullstruct.To[pgtype.Int2](nullable.FromValue(math.MaxInt16 + 1))
+		assert.ErrorContains(t, err, "overflows")
+
+		_, err = nullstruct.To[pgtype.Uint32](nullable.FromValue(-1))
+		assert.ErrorContains(t, err, "overflows")
+
+		_, err = nullstruct.To[pgtype.Int8](nullable.FromValue(uint64(math.MaxUint64)))
+		assert.ErrorContains(t, err, "overflows")
+
+		_, err = nullstruct.From[int16](pgtype.Int4{Int32: math.MaxInt16 + 1, Valid: true})
+		assert.ErrorContains(t, err, "overflows")
+
+		_, err = nullstruct.From[int32](pgtype.Int8{Int64: math.MinInt32 - 1, Valid: true})
+		assert.ErrorContains(t, err, "overflows")
+
+		_, err = nullstruct.From[int64](pgtype.Uint32{Uint32: math.MaxUint32, Valid: true})
+		assert.NoError(t, err)
+
+		i, err := nullstruct.From[int16](pgtype.Int4{Int32: 32, Valid: true})
+		require.NoError(t, err)
+		assert.Equal(t, nullable.FromValue(int16(32)), i)
+	})
+
+	t.Run("Infinity", func(t *testing.T) {
+		_, err := nullstruct.From[time.Time](pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true})
+		assert.ErrorContains(t, err, "infinite")
+
+		_, err = nullstruct.From[time.Time](pgtype.Timestamp{InfinityModifier: pgtype.NegativeInfinity, Valid: true})
+		assert.ErrorContains(t, err, "infinite")
+
+		ts, err := nullstruct.From[time.Time](pgtype.Timestamptz{InfinityModifier: pgtype.Infinity})
+		require.NoError(t, err)
+		assert.True(t, ts.IsNull(), "a null infinite value is null")
+	})
+
+	t.Run("Invalid structs", func(t *testing.T) {
+		_, err := nullstruct.From[string](pgtype.Int8{Int64: 65, Valid: true})
+		assert.ErrorContains(t, err, "no field for string", "an integer is not converted to a string")
+
+		_, err = nullstruct.From[string](struct{ String string }{"a"})
+		assert.ErrorContains(t, err, "no Valid bool field")
+
+		_, err = nullstruct.To[string](nullable.FromValue("a"))
+		assert.ErrorContains(t, err, "not a struct")
+	})
+}
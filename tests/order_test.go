@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	one := nullable.FromValue(1)
+	two := nullable.FromValue(2)
+	null := nullable.Null[int]()
+
+	t.Run("Values", func(t *testing.T) {
+		assert.Equal(t, -1, nullable.Compare(one, two, false))
+		assert.Equal(t, 1, nullable.Compare(two, one, true))
+		assert.Equal(t, 0, nullable.Compare(one, nullable.FromValue(1), false))
+		assert.True(t, nullable.Less(one, two, false))
+		assert.False(t, nullable.Less(two, one, true))
+	})
+
+	t.Run("Nulls last", func(t *testing.T) {
+		assert.Equal(t, 1, nullable.Compare(null, one, false))
+		assert.Equal(t, -1, nullable.Compare(one, null, false))
+		assert.Equal(t, 0, nullable.Compare(null, null, false))
+		assert.True(t, nullable.Less(one, null, false))
+	})
+
+	t.Run("Nulls first", func(t *testing.T) {
+		assert.Equal(t, -1, nullable.Compare(null, one, true))
+		assert.Equal(t, 1, nullable.Compare(one, null, true))
+		assert.Equal(t, 0, nullable.Compare(null, null, true))
+		assert.True(t, nullable.Less(null, one, true))
+	})
+
+	t.Run("UUID", func(t *testing.T) {
+		low := nullable.FromValue(uuid.MustParse("00000000-0000-0000-0000-000000000001"))
+		high := nullable.FromValue(uuid.MustParse("ff000000-0000-0000-0000-000000000000"))
+
+		assert.Equal(t, -1, nullable.CompareUUID(low, high, false))
+		assert.Equal(t, 1, nullable.CompareUUID(high, low, false))
+		assert.Equal(t, -1, nullable.CompareUUID(high, nullable.Null[uuid.UUID](), false))
+	})
+
+	t.Run("Time", func(t *testing.T) {
+		utc := time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)
+		sameInstant := nullable.FromValue(utc.In(time.FixedZone("", 2*60*60)))
+
+		assert.Equal(t, 0, nullable.CompareTime(nullable.FromValue(utc), sameInstant, false))
+		assert.Equal(t, -1, nullable.CompareTime(nullable.FromValue(utc.Add(-time.Second)), sameInstant, false))
+		assert.Equal(t, -1, nullable.CompareTime(nullable.Null[time.Time](), sameInstant, true))
+	})
+}
+
+func TestEqualNulls(t *testing.T) {
+	one := nullable.FromValue(1)
+	null := nullable.Null[int]()
+
+	assert.True(t, nullable.Equal(one, nullable.FromValue(1), false), "values are compared, not pointers")
+	assert.False(t, nullable.Equal(one, nullable.FromValue(2), true))
+	assert.False(t, nullable.Equal(one, null, true))
+	assert.False(t, nullable.Equal(null, one, true))
+
+	assert.True(t, nullable.Equal(null, null, true), "NULL IS NOT DISTINCT FROM NULL")
+	assert.False(t, nullable.Equal(null, null, false), "NULL = NULL")
+}
+
+// TestCompareSQLite checks that sorting in memory gives the same order as ORDER BY.
+func TestCompareSQLite(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	values := []nullable.Of[int64]{
+		nullable.FromValue(int64(3)),
+		nullable.Null[int64](),
+		nullable.FromValue(int64(-1)),
+		nullable.FromValue(int64(2)),
+		nullable.Null[int64](),
+	}
+
+	for _, v := range values {
+		_, err := db.Exec("INSERT INTO type_test (int64_val) VALUES (?)", &v)
+		require.NoError(t, err)
+	}
+
+	for _, order := range []struct {
+		sql        string
+		nullsFirst bool
+		desc       bool
+	}{
+		{"int64_val ASC NULLS LAST", false, false},
+		{"int64_val ASC NULLS FIRST", true, false},
+		{"int64_val DESC NULLS LAST", true, true},
+		{"int64_val DESC NULLS FIRST", false, true},
+	} {
+		t.Run(order.sql, func(t *testing.T) {
+			rows, err := db.Query("SELECT int64_val FROM type_test ORDER BY " + order.sql)
+			require.NoError(t, err)
+
+			defer rows.Close()
+
+			var want []nullable.Of[int64]
+			for rows.Next() {
+				var v nullable.Of[int64]
+				require.NoError(t, rows.Scan(&v))
+				want = append(want, v)
+			}
+
+			require.NoError(t, rows.Err())
+
+			sorted := slices.Clone(values)
+			slices.SortFunc(sorted, func(a, b nullable.Of[int64]) int {
+				if order.desc {
+					a, b = b, a
+				}
+
+				return nullable.Compare(a, b, order.nullsFirst)
+			})
+
+			assert.Equal(t, want, sorted)
+		})
+	}
+}
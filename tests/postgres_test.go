@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -102,6 +103,38 @@ func TestAllTypes(t *testing.T) {
 	})
 }
 
+// TestIntegerAndUUIDValues round-trips the integers, written as int64, and the UUIDs, written as text,
+// at the bounds of their columns.
+func TestIntegerAndUUIDValues(t *testing.T) {
+	db := getDB(t)
+	cleanupTables(t, db, "type_test")
+
+	testUUID := uuid.New()
+
+	var (
+		id      int64
+		read    TypeTest
+		uuidStr string
+	)
+
+	err := db.QueryRow(`INSERT INTO type_test (int_val, int16_val, int32_val, uuid_val)
+		VALUES ($1, $2, $3, $4) RETURNING id`,
+		nullable.FromValue(math.MaxInt32), nullable.FromValue(int16(math.MinInt16)),
+		nullable.FromValue(int32(math.MaxInt32)), nullable.FromValue(testUUID),
+	).Scan(&id)
+	require.NoError(t, err, "Insert failed")
+
+	err = db.QueryRow(`SELECT int_val, int16_val, int32_val, uuid_val, uuid_val::text FROM type_test WHERE id = $1`, id).
+		Scan(&read.IntVal, &read.Int16Val, &read.Int32Val, &read.UUIDVal, &uuidStr)
+	require.NoError(t, err, "Read failed")
+
+	assert.Equal(t, math.MaxInt32, *read.IntVal.GetValue())
+	assert.Equal(t, int16(math.MinInt16), *read.Int16Val.GetValue())
+	assert.Equal(t, int32(math.MaxInt32), *read.Int32Val.GetValue())
+	assert.Equal(t, testUUID, *read.UUIDVal.GetValue())
+	assert.Equal(t, testUUID.String(), uuidStr)
+}
+
 func TestReadExisting(t *testing.T) {
 	db := getDB(t)
 
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		var got []string
+		for v := range nullable.FromValue("a").All() {
+			got = append(got, v)
+		}
+
+		assert.Equal(t, []string{"a"}, got)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		for range nullable.Null[string]().All() {
+			t.Fatal("the body must not run for a null")
+		}
+	})
+
+	t.Run("Collect", func(t *testing.T) {
+		assert.Equal(t, []int{42}, slices.Collect(nullable.FromValue(42).All()))
+		assert.Empty(t, slices.Collect(nullable.Null[int]().All()))
+	})
+}
+
+func TestSeqHelpers(t *testing.T) {
+	values := []nullable.Of[string]{
+		nullable.Null[string](),
+		nullable.FromValue("a"),
+		nullable.Null[string](),
+		nullable.FromValue("b"),
+		nullable.FromValue("c"),
+	}
+
+	t.Run("Values", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(nullable.Values(slices.Values(values))))
+		assert.Empty(t, slices.Collect(nullable.Values(slices.Values([]nullable.Of[string]{nullable.Null[string]()}))))
+	})
+
+	t.Run("Indexed", func(t *testing.T) {
+		assert.Equal(t, map[int]string{1: "a", 3: "b", 4: "c"}, maps.Collect(nullable.Indexed(slices.Values(values))))
+	})
+
+	t.Run("Early stop", func(t *testing.T) {
+		var got []string
+		for v := range nullable.Values(slices.Values(values)) {
+			got = append(got, v)
+			if v == "b" {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"a", "b"}, got)
+
+		var indexes []int
+		for i := range nullable.Indexed(slices.Values(values)) {
+			indexes = append(indexes, i)
+			break
+		}
+
+		assert.Equal(t, []int{1}, indexes)
+	})
+}
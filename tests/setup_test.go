@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -23,10 +24,18 @@ var aint = 42
 
 // Package-level variables for shared test infrastructure
 var (
-	testDB        *sql.DB                      // Shared database connection
-	testContainer *postgres.PostgresContainer  // Container reference for cleanup
+	testDB        *sql.DB                     // Shared database connection
+	testContainer *postgres.PostgresContainer // Container reference for cleanup
+	postgresSkip  string                      // Reason to skip the PostgreSQL tests
+	postgresErr   error                       // Error starting the PostgreSQL container, failing the PostgreSQL tests
 )
 
+// skipPostgresEnv is the environment variable which skips the PostgreSQL tests when set.
+const skipPostgresEnv = "NULLABLE_SKIP_POSTGRES"
+
+// errNoDockerHost is returned by runPostgres when testcontainers finds no Docker host.
+var errNoDockerHost = errors.New("no Docker host found")
+
 type embeddedStruct struct {
 	ID     int64                      `json:"id" db:"id"`
 	String string                     `json:"string" db:"string"`
@@ -47,20 +56,23 @@ type testedStruct[T nullable.JSON] struct {
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 
-	// Create PostgreSQL container with testcontainers
-	container, err := postgres.Run(ctx,
-		"postgres:18-alpine",
-		postgres.WithInitScripts("init.sql"),
-		postgres.WithDatabase("testdb"),
-		postgres.WithUsername("testuser"),
-		postgres.WithPassword("testpass"),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(60*time.Second)),
-	)
-	if err != nil {
-		log.Fatalf("Failed to start PostgreSQL container: %v", err)
+	if os.Getenv(skipPostgresEnv) != "" {
+		postgresSkip = skipPostgresEnv + " is set"
+		os.Exit(m.Run())
+	}
+
+	container, err := runPostgres(ctx)
+	switch {
+	case errors.Is(err, errNoDockerHost):
+		// Docker is not available: only the tests which don't need PostgreSQL are run
+		postgresSkip = err.Error()
+		log.Printf("PostgreSQL tests are skipped: %v", err)
+		os.Exit(m.Run())
+	case err != nil:
+		// Docker is available but the container failed to start: the PostgreSQL tests fail with the error
+		postgresErr = err
+		log.Printf("Failed to start PostgreSQL container: %v", err)
+		os.Exit(m.Run())
 	}
 	testContainer = container
 
@@ -98,12 +110,39 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-// getDB returns the shared test database connection
+// runPostgres creates the PostgreSQL container with testcontainers.
+// testcontainers panics when no Docker host is found, the panic is returned as errNoDockerHost.
+func runPostgres(ctx context.Context) (container *postgres.PostgresContainer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errNoDockerHost, r)
+		}
+	}()
+
+	return postgres.Run(ctx,
+		"postgres:18-alpine",
+		postgres.WithInitScripts("init.sql"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+}
+
+// getDB returns the shared test database connection.
+// The test is skipped if no Docker host is found or NULLABLE_SKIP_POSTGRES is set,
+// and fails if the PostgreSQL container could not be started.
 func getDB(t *testing.T) *sql.DB {
 	t.Helper()
 
-	if testDB == nil {
-		t.Fatal("testDB is nil - TestMain may not have run")
+	switch {
+	case postgresSkip != "":
+		t.Skipf("PostgreSQL is not available: %s", postgresSkip)
+	case postgresErr != nil:
+		t.Fatalf("Failed to start PostgreSQL container: %v", postgresErr)
 	}
 
 	return testDB
@@ -0,0 +1,334 @@
+package tests
+
+import (
+	"database/sql"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+// getSQLiteDB returns a new SQLite database initialized with init_sqlite.sql.
+// It runs in-process with a pure Go driver, so it doesn't need Docker.
+func getSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err, "Failed to open SQLite database")
+
+	t.Cleanup(func() { db.Close() })
+
+	script, err := os.ReadFile("init_sqlite.sql")
+	require.NoError(t, err, "Failed to read init_sqlite.sql")
+
+	_, err = db.Exec(string(script))
+	require.NoError(t, err, "Failed to initialize SQLite database")
+
+	return db
+}
+
+func TestSQLiteAllTypes(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	testUUID := uuid.New()
+	testTime := time.Now().UTC().Truncate(time.Second)
+
+	typeTest := TypeTest{
+		StringVal: nullable.FromValue("test string"),
+		IntVal:    nullable.FromValue(aint),
+		Int16Val:  nullable.FromValue(int16(16)),
+		Int32Val:  nullable.FromValue(int32(32)),
+		Int64Val:  nullable.FromValue(int64(64)),
+		FloatVal:  nullable.FromValue(3.14),
+		BoolVal:   nullable.FromValue(true),
+		UUIDVal:   nullable.FromValue(testUUID),
+		TimeVal:   nullable.FromValue(testTime),
+		JSONVal:   nullable.FromValue[nullable.JSON](map[string]any{"key": "value"}),
+	}
+
+	var insertedID int64
+	t.Run("Writing data into database", func(t *testing.T) {
+		err := db.QueryRow(`
+		INSERT INTO type_test (
+			string_val, int_val, int16_val, int32_val, int64_val,
+			float_val, bool_val, uuid_val, time_val, json_val
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`,
+			&typeTest.StringVal, &typeTest.IntVal, &typeTest.Int16Val, &typeTest.Int32Val,
+			&typeTest.Int64Val, &typeTest.FloatVal, &typeTest.BoolVal, &typeTest.UUIDVal,
+			&typeTest.TimeVal, &typeTest.JSONVal,
+		).Scan(&insertedID)
+		require.NoError(t, err, "Insert types failed")
+	})
+
+	var readTest TypeTest
+	t.Run("Reading data from database", func(t *testing.T) {
+		err := db.QueryRow(`
+		SELECT id, string_val, int_val, int16_val, int32_val, int64_val,
+			float_val, bool_val, uuid_val, time_val, json_val
+		FROM type_test WHERE id = ?`,
+			insertedID,
+		).Scan(
+			&readTest.ID, &readTest.StringVal, &readTest.IntVal, &readTest.Int16Val,
+			&readTest.Int32Val, &readTest.Int64Val, &readTest.FloatVal, &readTest.BoolVal,
+			&readTest.UUIDVal, &readTest.TimeVal, &readTest.JSONVal,
+		)
+		require.NoError(t, err, "Read types failed")
+	})
+
+	t.Run("data maching read <-> write", func(t *testing.T) {
+		assert.Equal(t, "test string", *readTest.StringVal.GetValue())
+		assert.Equal(t, aint, *readTest.IntVal.GetValue())
+		assert.Equal(t, int16(16), *readTest.Int16Val.GetValue())
+		assert.Equal(t, int32(32), *readTest.Int32Val.GetValue())
+		assert.Equal(t, int64(64), *readTest.Int64Val.GetValue())
+		assert.Equal(t, 3.14, *readTest.FloatVal.GetValue())
+		assert.True(t, *readTest.BoolVal.GetValue())
+		assert.Equal(t, testUUID, *readTest.UUIDVal.GetValue())
+		assert.True(t, testTime.Equal(*readTest.TimeVal.GetValue()), "time mismatch")
+		assert.Equal(t, map[string]any{"key": "value"}, *readTest.JSONVal.GetValue())
+	})
+}
+
+// TestSQLiteIntegerAndUUIDValues round-trips the integers, written as int64, and the UUIDs, written as text:
+// database/sql rejects the other driver values with SQLite.
+func TestSQLiteIntegerAndUUIDValues(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	testUUID := uuid.New()
+
+	var (
+		id    int64
+		read  TypeTest
+		types [4]string
+	)
+
+	err := db.QueryRow(`INSERT INTO type_test (int_val, int16_val, int32_val, uuid_val) VALUES (?, ?, ?, ?) RETURNING id`,
+		nullable.FromValue(math.MaxInt), nullable.FromValue(int16(math.MinInt16)),
+		nullable.FromValue(int32(math.MaxInt32)), nullable.FromValue(testUUID),
+	).Scan(&id)
+	require.NoError(t, err, "Insert failed")
+
+	err = db.QueryRow(`SELECT int_val, int16_val, int32_val, uuid_val,
+		typeof(int_val), typeof(int16_val), typeof(int32_val), typeof(uuid_val) FROM type_test WHERE id = ?`, id).
+		Scan(&read.IntVal, &read.Int16Val, &read.Int32Val, &read.UUIDVal, &types[0], &types[1], &types[2], &types[3])
+	require.NoError(t, err, "Read failed")
+
+	assert.Equal(t, math.MaxInt, *read.IntVal.GetValue())
+	assert.Equal(t, int16(math.MinInt16), *read.Int16Val.GetValue())
+	assert.Equal(t, int32(math.MaxInt32), *read.Int32Val.GetValue())
+	assert.Equal(t, testUUID, *read.UUIDVal.GetValue())
+	assert.Equal(t, [4]string{"integer", "integer", "integer", "text"}, types)
+}
+
+func TestSQLiteReadExisting(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	rows, err := db.Query("SELECT id, name, date_to, data FROM test ORDER BY id")
+	require.NoError(t, err, "Query failed")
+
+	defer rows.Close()
+
+	var tests []testedStruct[embeddedStruct]
+	for rows.Next() {
+		var test testedStruct[embeddedStruct]
+		require.NoError(t, rows.Scan(&test.ID, &test.Name, &test.DateTo, &test.Data), "Scan failed")
+		tests = append(tests, test)
+	}
+
+	require.NoError(t, rows.Err())
+	require.Len(t, tests, 3)
+
+	assert.False(t, tests[0].DateTo.IsNull(), "CURRENT_TIMESTAMP should be scanned")
+	assert.Equal(t, aint, tests[0].Data.GetValue().Int)
+	assert.True(t, *tests[0].Data.GetValue().Bool.GetValue())
+
+	assert.True(t, tests[1].Name.IsNull())
+	assert.True(t, tests[1].DateTo.IsNull())
+	assert.True(t, tests[1].Data.IsNull())
+
+	assert.Equal(t, time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC), *tests[2].DateTo.GetValue())
+}
+
+func TestSQLiteNullValues(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	test := testedStruct[embeddedStruct]{
+		Name:   nullable.Null[string](),
+		DateTo: nullable.Null[time.Time](),
+		Data:   nullable.Null[embeddedStruct](),
+	}
+
+	var insertedID int64
+
+	t.Run("Inserting", func(t *testing.T) {
+		err := db.QueryRow(
+			"INSERT INTO test (name, date_to, data) VALUES (?, ?, ?) RETURNING id",
+			&test.Name, &test.DateTo, &test.Data,
+		).Scan(&insertedID)
+		require.NoError(t, err, "Insert NULL failed")
+	})
+
+	var readTest testedStruct[nullable.JSON]
+
+	t.Run("Reading and scanning", func(t *testing.T) {
+		err := db.QueryRow(
+			"SELECT id, name, date_to, data FROM test WHERE id = ?",
+			insertedID,
+		).Scan(&readTest.ID, &readTest.Name, &readTest.DateTo, &readTest.Data)
+		require.NoError(t, err, "Read NULL failed")
+	})
+
+	t.Run("Verify all values are null", func(t *testing.T) {
+		assert.True(t, readTest.Name.IsNull(), "Name should be NULL")
+		assert.True(t, readTest.DateTo.IsNull(), "DateTo should be NULL")
+		assert.True(t, readTest.Data.IsNull(), "Data should be NULL")
+	})
+}
+
+func TestSQLiteInsertAndRead(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	data := getEmbeddedObj()
+
+	test := testedStruct[embeddedStruct]{
+		Name:   nullable.FromValue(name),
+		DateTo: nullable.FromValue(now),
+		Data:   nullable.FromValue(data),
+	}
+
+	var insertedID int64
+
+	t.Run("Inserting", func(t *testing.T) {
+		err := db.QueryRow(
+			"INSERT INTO test (name, date_to, data) VALUES (?, ?, ?) RETURNING id",
+			&test.Name, &test.DateTo, &test.Data,
+		).Scan(&insertedID)
+		require.NoError(t, err, "Insert failed")
+	})
+
+	var readTest testedStruct[embeddedStruct]
+
+	t.Run("Reading back", func(t *testing.T) {
+		err := db.QueryRow(
+			"SELECT id, name, date_to, data FROM test WHERE id = ?",
+			insertedID,
+		).Scan(&readTest.ID, &readTest.Name, &readTest.DateTo, &readTest.Data)
+		require.NoError(t, err, "Read failed")
+	})
+
+	t.Run("Data matching", func(t *testing.T) {
+		require.NotNil(t, readTest.Name.GetValue(), "Name should not be null")
+		assert.Equal(t, name, *readTest.Name.GetValue())
+
+		require.False(t, readTest.DateTo.IsNull(), "DateTo should not be null")
+		assert.WithinDuration(t, now, *readTest.DateTo.GetValue(), time.Millisecond)
+
+		require.NotNil(t, readTest.Data.GetValue(), "Data should not be null")
+		assert.Equal(t, astring, readTest.Data.GetValue().String)
+
+		require.NotNil(t, readTest.Data.GetValue().Bool.GetValue(), "Data.Bool should not be null")
+		assert.True(t, *readTest.Data.GetValue().Bool.GetValue(), "Data.Bool should be true")
+
+		require.NotNil(t, readTest.Data.GetValue().DateTo.GetValue(), "Data.DateTo should not be null")
+		assert.WithinDuration(t, now, *readTest.Data.GetValue().DateTo.GetValue(), time.Millisecond)
+	})
+}
+
+func TestSQLiteTextTypes(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	date := nullable.FromValue(nullable.Date{Year: 2024, Month: time.February, Day: 29})
+	timeOfDay := nullable.FromValue(nullable.TimeOfDay{Hour: 13, Minute: 45, Second: 30, Nanosecond: 500000000})
+	duration := nullable.FromValue(26*time.Hour + 3*time.Minute)
+	addr := nullable.FromValue(netip.MustParseAddr("192.168.1.10"))
+	prefix := nullable.FromValue(netip.MustParsePrefix("10.0.0.0/8"))
+	st := nullable.FromValue(statusActive)
+
+	var insertedID int64
+	err := db.QueryRow(`
+	INSERT INTO text_type_test (date_val, time_val, duration_val, addr_val, prefix_val, status_val)
+	VALUES (?, ?, ?, ?, ?, ?) RETURNING id`,
+		&date, &timeOfDay, &duration, &addr, &prefix, &st,
+	).Scan(&insertedID)
+	require.NoError(t, err, "Insert failed")
+
+	t.Run("Values", func(t *testing.T) {
+		var (
+			readDate      nullable.Of[nullable.Date]
+			readTimeOfDay nullable.Of[nullable.TimeOfDay]
+			readDuration  nullable.Of[time.Duration]
+			readAddr      nullable.Of[netip.Addr]
+			readPrefix    nullable.Of[netip.Prefix]
+			readStatus    nullable.Of[status]
+		)
+
+		err := db.QueryRow(`
+		SELECT date_val, time_val, duration_val, addr_val, prefix_val, status_val
+		FROM text_type_test WHERE id = ?`,
+			insertedID,
+		).Scan(&readDate, &readTimeOfDay, &readDuration, &readAddr, &readPrefix, &readStatus)
+		require.NoError(t, err, "Read failed")
+
+		assert.Equal(t, *date.GetValue(), *readDate.GetValue())
+		assert.Equal(t, *timeOfDay.GetValue(), *readTimeOfDay.GetValue())
+		assert.Equal(t, *duration.GetValue(), *readDuration.GetValue())
+		assert.Equal(t, *addr.GetValue(), *readAddr.GetValue())
+		assert.Equal(t, *prefix.GetValue(), *readPrefix.GetValue())
+		assert.Equal(t, statusActive, *readStatus.GetValue())
+	})
+
+	t.Run("Nulls", func(t *testing.T) {
+		_, err := db.Exec("INSERT INTO text_type_test DEFAULT VALUES")
+		require.NoError(t, err)
+
+		var (
+			readDate      nullable.Of[nullable.Date]
+			readTimeOfDay nullable.Of[nullable.TimeOfDay]
+			readDuration  nullable.Of[time.Duration]
+			readAddr      nullable.Of[netip.Addr]
+			readPrefix    nullable.Of[netip.Prefix]
+			readStatus    nullable.Of[status]
+		)
+
+		err = db.QueryRow(`
+		SELECT date_val, time_val, duration_val, addr_val, prefix_val, status_val
+		FROM text_type_test WHERE id <> ?`,
+			insertedID,
+		).Scan(&readDate, &readTimeOfDay, &readDuration, &readAddr, &readPrefix, &readStatus)
+		require.NoError(t, err, "Read failed")
+
+		assert.True(t, readDate.IsNull())
+		assert.True(t, readTimeOfDay.IsNull())
+		assert.True(t, readDuration.IsNull())
+		assert.True(t, readAddr.IsNull())
+		assert.True(t, readPrefix.IsNull())
+		assert.True(t, readStatus.IsNull())
+	})
+}
+
+func TestSQLiteTimestampAsText(t *testing.T) {
+	db := getSQLiteDB(t)
+
+	// The driver only parses timestamps of columns declared as DATE, DATETIME or TIMESTAMP:
+	// aggregates return the text stored by the driver, holding the monotonic clock reading.
+	var maxDate nullable.Of[time.Time]
+	err := db.QueryRow("SELECT max(date_to) FROM test").Scan(&maxDate)
+	require.NoError(t, err, "Read failed")
+	require.False(t, maxDate.IsNull())
+
+	written := time.Now()
+	_, err = db.Exec("INSERT INTO test (date_to) VALUES (?)", written)
+	require.NoError(t, err)
+
+	err = db.QueryRow("SELECT max(date_to) FROM test").Scan(&maxDate)
+	require.NoError(t, err, "Read failed")
+	assert.True(t, written.Equal(*maxDate.GetValue()), "time mismatch")
+}
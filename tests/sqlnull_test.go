@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ovya/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLNull(t *testing.T) {
+	t.Run("Generic", func(t *testing.T) {
+		assert.Equal(t, nullable.FromValue("a"), nullable.FromSQLNull(sql.Null[string]{V: "a", Valid: true}))
+		assert.Equal(t, nullable.Null[string](), nullable.FromSQLNull(sql.Null[string]{V: "ignored"}))
+
+		assert.Equal(t, sql.Null[int]{V: 42, Valid: true}, nullable.ToSQLNull(nullable.FromValue(42)))
+		assert.Equal(t, sql.Null[int]{}, nullable.ToSQLNull(nullable.Null[int]()))
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		assert.Equal(t, nullable.FromValue("a"), nullable.FromNullString(sql.NullString{String: "a", Valid: true}))
+		assert.Equal(t, nullable.FromValue(int64(64)), nullable.FromNullInt64(sql.NullInt64{Int64: 64, Valid: true}))
+		assert.Equal(t, nullable.FromValue(int32(32)), nullable.FromNullInt32(sql.NullInt32{Int32: 32, Valid: true}))
+		assert.Equal(t, nullable.FromValue(int16(16)), nullable.FromNullInt16(sql.NullInt16{Int16: 16, Valid: true}))
+		assert.Equal(t, nullable.FromValue(byte(8)), nullable.FromNullByte(sql.NullByte{Byte: 8, Valid: true}))
+		assert.Equal(t, nullable.FromValue(1.5), nullable.FromNullFloat64(sql.NullFloat64{Float64: 1.5, Valid: true}))
+		assert.Equal(t, nullable.FromValue(true), nullable.FromNullBool(sql.NullBool{Bool: true, Valid: true}))
+		assert.Equal(t, nullable.FromValue(now), nullable.FromNullTime(sql.NullTime{Time: now, Valid: true}))
+
+		assert.Equal(t, nullable.Null[string](), nullable.FromNullString(sql.NullString{String: "ignored"}))
+		assert.Equal(t, nullable.Null[time.Time](), nullable.FromNullTime(sql.NullTime{}))
+
+		assert.Equal(t, sql.NullString{String: "a", Valid: true}, nullable.ToNullString(nullable.FromValue("a")))
+		assert.Equal(t, sql.NullInt64{Int64: 64, Valid: true}, nullable.ToNullInt64(nullable.FromValue(int64(64))))
+		assert.Equal(t, sql.NullInt32{Int32: 32, Valid: true}, nullable.ToNullInt32(nullable.FromValue(int32(32))))
+		assert.Equal(t, sql.NullInt16{Int16: 16, Valid: true}, nullable.ToNullInt16(nullable.FromValue(int16(16))))
+		assert.Equal(t, sql.NullByte{Byte: 8, Valid: true}, nullable.ToNullByte(nullable.FromValue(byte(8))))
+		assert.Equal(t, sql.NullFloat64{Float64: 1.5, Valid: true}, nullable.ToNullFloat64(nullable.FromValue(1.5)))
+		assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, nullable.ToNullBool(nullable.FromValue(true)))
+		assert.Equal(t, sql.NullTime{Time: now, Valid: true}, nullable.ToNullTime(nullable.FromValue(now)))
+
+		assert.Equal(t, sql.NullString{}, nullable.ToNullString(nullable.Null[string]()))
+		assert.Equal(t, sql.NullBool{}, nullable.ToNullBool(nullable.Null[bool]()))
+	})
+
+	t.Run("Byte as the database and JSON value of sql.NullByte", func(t *testing.T) {
+		n := nullable.FromNullByte(sql.NullByte{Byte: 5, Valid: true})
+
+		v, err := n.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), v)
+
+		b, err := json.Marshal(n)
+		require.NoError(t, err)
+		assert.JSONEq(t, "5", string(b))
+
+		var scanned nullable.Of[byte]
+		require.NoError(t, scanned.Scan(int64(200)))
+		assert.Equal(t, byte(200), *scanned.GetValue())
+
+		require.NoError(t, scanned.Scan(nil))
+		assert.True(t, scanned.IsNull())
+
+		assert.Error(t, scanned.Scan(int64(256)), "overflow must be detected as for sql.NullByte")
+	})
+
+	t.Run("Pointers", func(t *testing.T) {
+		v := 42
+		n := nullable.FromPtr(&v)
+		v = 0
+
+		assert.Equal(t, 42, *n.GetValue(), "FromPtr copies the value")
+		assert.Equal(t, nullable.Null[int](), nullable.FromPtr[int](nil))
+
+		p := nullable.ToPtr(n)
+		*p = 0
+
+		assert.Equal(t, 42, *n.GetValue(), "ToPtr copies the value")
+		assert.Nil(t, nullable.ToPtr(nullable.Null[int]()))
+	})
+}
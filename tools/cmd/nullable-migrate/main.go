@@ -0,0 +1,113 @@
+/*
+Command nullable-migrate rewrites the uses of the nullable types of database/sql,
+such as sql.NullString, sql.NullInt64, sql.NullTime or sql.Null[T], to nullable.Of[T].
+
+Usage:
+
+	nullable-migrate [-diff] [packages]
+
+The packages, the current one by default, and their tests are rewritten in place.
+With -diff, the files are not modified and the changes are printed as a unified diff.
+
+The rewritings are:
+
+	sql.NullString                          nullable.Of[string]
+	sql.NullString{String: s, Valid: true}  nullable.FromValue(s)
+	sql.NullString{}                        nullable.Null[string]()
+	sql.NullString{String: s, Valid: ok}    nullable.FromNullString(sql.NullString{String: s, Valid: ok})
+	x.Valid                                 !x.IsNull()
+	!x.Valid                                x.IsNull()
+	x.String                                *x.GetValue()
+	x.String = s; x.Valid = true            x.SetValue(s)
+	x.Valid = false                         x.SetNull()
+
+The uses which can't be rewritten, such as &x.String or x.Valid = ok, are reported with their position
+and left as is: the code doesn't compile until they are fixed by hand. The reads of x.String which are not
+guarded by an if x.Valid check, or by a preceding if !x.Valid { return }, are reported too: unlike x.String,
+*x.GetValue() panics if x is null. The exit status is 1 if any is reported.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ovya/nullable/tools/internal/migrate"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("nullable-migrate: ")
+
+	diff := flag.Bool("diff", false, "print the changes as a unified diff instead of rewriting the files")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: nullable-migrate [-diff] [packages]")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	results, err := migrate.Run("", patterns...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	warned := false
+
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			warning.Pos.Filename = relative(wd, warning.Pos.Filename)
+			fmt.Fprintln(os.Stderr, warning)
+
+			warned = true
+		}
+
+		if !result.Changed() {
+			continue
+		}
+
+		if *diff {
+			os.Stdout.Write(migrate.Diff(relative(wd, result.Filename), result.Before, result.After))
+
+			continue
+		}
+
+		info, err := os.Stat(result.Filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = os.WriteFile(result.Filename, result.After, info.Mode().Perm())
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if warned {
+		os.Exit(1)
+	}
+}
+
+// relative returns the path of filename relative to the directory wd if possible.
+func relative(wd, filename string) string {
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil {
+		return filename
+	}
+
+	return filepath.ToSlash(rel)
+}
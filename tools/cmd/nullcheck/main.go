@@ -0,0 +1,24 @@
+/*
+Command nullcheck reports the misuses of nullable.Of[T]: the dereferences of GetValue()
+not guarded by an IsNull check, the comparisons of nullable.Of values with == or !=,
+and the writes through GetValue() to a value shared by copies.
+See the package github.com/ovya/nullable/tools/nullcheck for the details.
+
+Usage:
+
+	nullcheck [-fix] [packages]
+
+It can also be run by go vet, with the other analyzers of go vet disabled:
+
+	go vet -vettool=$(which nullcheck) [packages]
+*/
+package main
+
+import (
+	"github.com/ovya/nullable/tools/nullcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(nullcheck.Analyzer)
+}
@@ -0,0 +1,78 @@
+/*
+Command nullgen generates a concrete nullable type with the methods of nullable.Of[T] for a type T:
+IsNull, GetValue, SetValue, SetValueP, SetNull, MarshalJSON, UnmarshalJSON, Value and Scan,
+and the conversions from and to nullable.Of[T]. It is meant for the tools which handle generic types poorly,
+such as some ORMs, mock generators or reflection-based mappers.
+
+Usage:
+
+	nullgen -type NullXxx -elem T [-package name] [-output file]
+
+T is a type of the package, a predeclared type, or a type of another package given by its import path,
+such as time.Time or github.com/shopspring/decimal.Decimal. The package defaults to $GOPACKAGE
+and the output file to nullxxx_nullgen.go, so that nullgen is usually run by go generate:
+
+	//go:generate go run github.com/ovya/nullable/tools/cmd/nullgen -type NullEmail -elem Email
+
+generates in nullemail_nullgen.go:
+
+	type NullEmail struct { ... }
+
+	func NullEmailFrom(n nullable.Of[Email]) NullEmail
+	func (n NullEmail) Of() nullable.Of[Email]
+	func (n *NullEmail) IsNull() bool
+	func (n *NullEmail) GetValue() *Email
+	...
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ovya/nullable/tools/internal/nullgen"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("nullgen: ")
+
+	typeName := flag.String("type", "", "name of the generated type, such as NullEmail")
+	elem := flag.String("elem", "", "type of the value, such as Email, time.Time or github.com/shopspring/decimal.Decimal")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	output := flag.String("output", "", "output file, nullxxx_nullgen.go by default")
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: nullgen -type NullXxx -elem T [-package name] [-output file]")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *typeName == "" || *elem == "" || flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := nullgen.Generate(nullgen.Config{
+		Package: *pkg,
+		Type:    *typeName,
+		Elem:    *elem,
+		Command: strings.Join(append([]string{"nullgen"}, os.Args[1:]...), " "),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_nullgen.go"
+	}
+
+	err = os.WriteFile(*output, src, 0o644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
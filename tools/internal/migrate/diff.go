@@ -0,0 +1,171 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines around the changes of a hunk.
+const context = 3
+
+// Diff returns the unified diff between before and after, the files being named name.
+func Diff(name string, before, after []byte) []byte {
+	a := splitLines(before)
+	b := splitLines(after)
+	ops := diffLines(a, b)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "--- a/%s\n+++ b/%s\n", name, name)
+
+	// Each hunk is made of the changes closer than 2*context lines, with context lines around.
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+
+			continue
+		}
+
+		end := start
+		for i := start; i < len(ops) && i-end <= 2*context; i++ {
+			if ops[i].kind != ' ' {
+				end = i
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context+1, len(ops))
+		writeHunk(&buf, ops[from:to])
+
+		start = to
+	}
+
+	return buf.Bytes()
+}
+
+// op is a line of a diff, of kind ' ' if unchanged, '-' if deleted or '+' if inserted.
+// aLine and bLine are the numbers of the line before the op in a and b.
+type op struct {
+	kind         byte
+	line         string
+	aLine, bLine int
+}
+
+func writeHunk(buf *bytes.Buffer, ops []op) {
+	aCount, bCount := 0, 0
+
+	for _, o := range ops {
+		if o.kind != '+' {
+			aCount++
+		}
+
+		if o.kind != '-' {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(ops[0].aLine, aCount), hunkRange(ops[0].bLine, bCount))
+
+	for _, o := range ops {
+		buf.WriteByte(o.kind)
+		buf.WriteString(o.line)
+
+		if !strings.HasSuffix(o.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange returns the range of a hunk starting after the line start.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	if count == 1 {
+		return fmt.Sprint(start + 1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines returns the ops transforming a into b, computed from their longest common subsequence.
+func diffLines(a, b []string) []op {
+	// Common prefix and suffix are trimmed to reduce the size of the table.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of ma[i:] and mb[j:].
+	lcs := make([][]int32, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(mb)+1)
+	}
+
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+
+	emit := func(kind byte, line string) {
+		ops = append(ops, op{kind: kind, line: line, aLine: i, bLine: j})
+
+		if kind != '+' {
+			i++
+		}
+
+		if kind != '-' {
+			j++
+		}
+	}
+
+	for _, line := range a[:prefix] {
+		emit(' ', line)
+	}
+
+	for i-prefix < len(ma) || j-prefix < len(mb) {
+		x, y := i-prefix, j-prefix
+
+		switch {
+		case x < len(ma) && y < len(mb) && ma[x] == mb[y]:
+			emit(' ', ma[x])
+		case x < len(ma) && (y == len(mb) || lcs[x+1][y] >= lcs[x][y+1]):
+			emit('-', ma[x])
+		default:
+			emit('+', mb[y])
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		emit(' ', line)
+	}
+
+	return ops
+}
+
+// splitLines splits data after each new line.
+func splitLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
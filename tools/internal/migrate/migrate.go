@@ -0,0 +1,761 @@
+// Package migrate rewrites the uses of the nullable types of database/sql, such as sql.NullString,
+// to nullable.Of[T]. It implements the nullable-migrate command.
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"os"
+	"slices"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+// nullablePath is the import path of the nullable package.
+const nullablePath = "github.com/ovya/nullable"
+
+// sqlType describes a nullable type of database/sql.
+type sqlType struct {
+	// elem is the type of the value, empty for sql.Null[T].
+	elem string
+	// field is the name of the field holding the value.
+	field string
+	// adapter is the nullable function converting the type to nullable.Of[T].
+	adapter string
+}
+
+// sqlTypes are the nullable types of database/sql by name.
+var sqlTypes = map[string]sqlType{
+	"NullString":  {elem: "string", field: "String", adapter: "FromNullString"},
+	"NullInt64":   {elem: "int64", field: "Int64", adapter: "FromNullInt64"},
+	"NullInt32":   {elem: "int32", field: "Int32", adapter: "FromNullInt32"},
+	"NullInt16":   {elem: "int16", field: "Int16", adapter: "FromNullInt16"},
+	"NullByte":    {elem: "byte", field: "Byte", adapter: "FromNullByte"},
+	"NullFloat64": {elem: "float64", field: "Float64", adapter: "FromNullFloat64"},
+	"NullBool":    {elem: "bool", field: "Bool", adapter: "FromNullBool"},
+	"NullTime":    {elem: "time.Time", field: "Time", adapter: "FromNullTime"},
+	"Null":        {field: "V", adapter: "FromSQLNull"},
+}
+
+// Warning is a use of a nullable type of database/sql which couldn't be rewritten.
+type Warning struct {
+	Pos     token.Position
+	Message string
+}
+
+// String returns the warning prefixed by its position.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Pos, w.Message)
+}
+
+// Result is the rewriting of a Go file.
+type Result struct {
+	Filename string
+	Before   []byte
+	After    []byte
+	Warnings []Warning
+}
+
+// Changed returns true iff the file is modified by the rewriting.
+func (r Result) Changed() bool {
+	return !bytes.Equal(r.Before, r.After)
+}
+
+// Run loads the packages matching patterns, with their tests, from the directory dir
+// and returns the rewriting of their files. The files are not modified.
+// The packages must compile.
+func Run(dir string, patterns ...string) ([]Result, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages : %w", err)
+	}
+
+	var errs []error
+
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("loading packages : %w", errors.Join(errs...))
+	}
+
+	var results []Result
+
+	seen := map[string]bool{}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			filename := pkg.Fset.File(file.Pos()).Name()
+
+			// Test variants of a package share its files.
+			if seen[filename] || !slices.Contains(pkg.GoFiles, filename) {
+				continue
+			}
+
+			seen[filename] = true
+
+			result, err := rewriteFile(pkg.Fset, file, pkg.TypesInfo, filename)
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// rewriteFile rewrites file, type-checked in info. The file is modified.
+func rewriteFile(fset *token.FileSet, file *ast.File, info *types.Info, filename string) (Result, error) {
+	before, err := os.ReadFile(filename)
+	if err != nil {
+		return Result{}, err
+	}
+
+	r := &rewriter{fset: fset, info: info, skip: map[ast.Node]bool{}, drop: map[ast.Stmt]bool{}}
+	r.apply(file)
+
+	result := Result{Filename: filename, Before: before, After: before, Warnings: r.warnings}
+	if !r.changed {
+		return result, nil
+	}
+
+	astutil.AddImport(fset, file, nullablePath)
+
+	if r.needTime {
+		astutil.AddImport(fset, file, "time")
+	}
+
+	if !astutil.UsesImport(file, "database/sql") {
+		astutil.DeleteImport(fset, file, "database/sql")
+	}
+
+	var buf bytes.Buffer
+
+	err = format.Node(&buf, fset, file)
+	if err != nil {
+		return Result{}, fmt.Errorf("formatting %s : %w", filename, err)
+	}
+
+	// Formatting the imports as goimports does moves the nullable import
+	// from the group of the standard library to its own group.
+	opts := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true}
+
+	result.After, err = imports.Process(filename, buf.Bytes(), opts)
+	if err != nil {
+		return Result{}, fmt.Errorf("formatting %s : %w", filename, err)
+	}
+
+	return result, nil
+}
+
+// guard is an expression x checked as valid, by if x.Valid or x.Valid && ..., in the range of positions [from, to).
+type guard struct {
+	x        string
+	from, to token.Pos
+}
+
+// rewriter rewrites the nodes of a file.
+type rewriter struct {
+	fset *token.FileSet
+	info *types.Info
+	// guards are the expressions checked as valid, see ifStmt and markGuards.
+	guards []guard
+	// skip are the nodes which must not be rewritten.
+	skip map[ast.Node]bool
+	// drop are the statements to delete.
+	drop     map[ast.Stmt]bool
+	changed  bool
+	needTime bool
+	warnings []Warning
+}
+
+// apply rewrites n and returns the rewritten node.
+func (r *rewriter) apply(n ast.Node) ast.Node {
+	return astutil.Apply(n, r.pre, nil)
+}
+
+// applyExpr rewrites the expression e and returns the rewritten expression.
+func (r *rewriter) applyExpr(e ast.Expr) ast.Expr {
+	return r.apply(e).(ast.Expr)
+}
+
+func (r *rewriter) pre(c *astutil.Cursor) bool {
+	if r.skip[c.Node()] {
+		return false
+	}
+
+	switch n := c.Node().(type) {
+	case *ast.IfStmt:
+		for _, x := range r.guardsOf(n.Cond) {
+			r.guards = append(r.guards, guard{x: x, from: n.Body.Pos(), to: n.Body.End()})
+		}
+	case *ast.BinaryExpr:
+		if n.Op == token.LAND {
+			for _, x := range r.guardsOf(n.X) {
+				r.guards = append(r.guards, guard{x: x, from: n.Y.Pos(), to: n.Y.End()})
+			}
+		}
+
+		// nullable.Of values are compared by their pointers: the comparisons are reported, not rewritten.
+		if (n.Op == token.EQL || n.Op == token.NEQ) && holdsSQLType(r.info.TypeOf(n.X)) {
+			r.warn(n, fmt.Sprintf("cannot rewrite the comparison %s: nullable.Of values compared with %s "+
+				"compare their pointers, use Equal", types.ExprString(n), n.Op))
+
+			return false
+		}
+	case *ast.MapType:
+		if holdsSQLType(r.info.TypeOf(n.Key)) {
+			r.warn(n, fmt.Sprintf("cannot rewrite the map key %s: nullable.Of keys are compared by their pointers, "+
+				"use their Key", types.ExprString(n.Key)))
+			r.skip[n.Key] = true
+		}
+	case *ast.SwitchStmt:
+		r.switchStmt(n)
+	case *ast.BlockStmt:
+		r.markDrops(n.List)
+		r.markGuards(n.List, n.End())
+	case *ast.CaseClause:
+		r.markDrops(n.Body)
+	case *ast.CommClause:
+		r.markDrops(n.Body)
+	case *ast.AssignStmt:
+		return r.assignStmt(c, n)
+	case *ast.IncDecStmt:
+		if _, _, ok := r.fieldOf(n.X); ok {
+			r.warn(n, "cannot rewrite the modification of "+types.ExprString(n.X))
+			r.skip[n.X] = true
+		}
+	case *ast.CompositeLit:
+		if st, elem, ok := r.typeExpr(n.Type); ok {
+			c.Replace(r.compositeLit(n, st, elem))
+			r.changed = true
+
+			return false
+		}
+	case *ast.IndexExpr, *ast.SelectorExpr:
+		if _, elem, ok := r.typeExpr(n.(ast.Expr)); ok {
+			c.Replace(&ast.IndexExpr{X: nullableSel("Of", n.Pos()), Index: elem, Lbrack: n.End(), Rbrack: n.End()})
+			r.changed = true
+
+			return false
+		}
+
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			return r.selectorExpr(c, sel)
+		}
+	case *ast.UnaryExpr:
+		return r.unaryExpr(c, n)
+	}
+
+	return true
+}
+
+// guardsOf returns the expressions x of the x.Valid conjuncts of cond.
+func (r *rewriter) guardsOf(cond ast.Expr) []string {
+	switch e := cond.(type) {
+	case *ast.ParenExpr:
+		return r.guardsOf(e.X)
+	case *ast.BinaryExpr:
+		if e.Op == token.LAND {
+			return append(r.guardsOf(e.X), r.guardsOf(e.Y)...)
+		}
+	case *ast.SelectorExpr:
+		if _, field, ok := r.fieldOf(e); ok && field == "Valid" {
+			return []string{types.ExprString(e.X)}
+		}
+	}
+
+	return nil
+}
+
+// markGuards marks the expressions x checked by a statement if !x.Valid { return } of list
+// as guards in the following statements of the block ending at end.
+func (r *rewriter) markGuards(list []ast.Stmt, end token.Pos) {
+	for _, s := range list {
+		n, ok := s.(*ast.IfStmt)
+		if !ok || n.Else != nil || len(n.Body.List) == 0 {
+			continue
+		}
+
+		if _, ok := n.Body.List[len(n.Body.List)-1].(*ast.ReturnStmt); !ok {
+			continue
+		}
+
+		for _, x := range r.nullGuardsOf(n.Cond) {
+			r.guards = append(r.guards, guard{x: x, from: n.End(), to: end})
+		}
+	}
+}
+
+// nullGuardsOf returns the expressions x of the !x.Valid disjuncts of cond.
+func (r *rewriter) nullGuardsOf(cond ast.Expr) []string {
+	switch e := cond.(type) {
+	case *ast.ParenExpr:
+		return r.nullGuardsOf(e.X)
+	case *ast.BinaryExpr:
+		if e.Op == token.LOR {
+			return append(r.nullGuardsOf(e.X), r.nullGuardsOf(e.Y)...)
+		}
+	case *ast.UnaryExpr:
+		if _, field, ok := r.fieldOf(e.X); ok && e.Op == token.NOT && field == "Valid" {
+			return []string{types.ExprString(e.X.(*ast.SelectorExpr).X)}
+		}
+	}
+
+	return nil
+}
+
+// isGuarded returns true iff the expression x at pos is checked as valid.
+func (r *rewriter) isGuarded(x string, pos token.Pos) bool {
+	return slices.ContainsFunc(r.guards, func(g guard) bool { return g.x == x && g.from <= pos && pos < g.to })
+}
+
+// markDrops marks the statements x.Valid = true following an assignment x.Field = v to be dropped,
+// the assignment being rewritten as x.SetValue(v).
+func (r *rewriter) markDrops(list []ast.Stmt) {
+	for i := 1; i < len(list); i++ {
+		x, ok := r.validAssign(list[i], true)
+		if !ok {
+			continue
+		}
+
+		if y, ok := r.valueAssign(list[i-1]); ok && x == y {
+			r.drop[list[i]] = true
+		}
+	}
+}
+
+// validAssign returns the expression x of a statement x.Valid = value.
+func (r *rewriter) validAssign(s ast.Stmt, value bool) (string, bool) {
+	assign, ok := s.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return "", false
+	}
+
+	sel, field, ok := r.fieldOf(assign.Lhs[0])
+	if !ok || field != "Valid" || !r.isBool(assign.Rhs[0], value) {
+		return "", false
+	}
+
+	return types.ExprString(sel.X), true
+}
+
+// valueAssign returns the expression x of a statement x.Field = v, Field being the value field.
+func (r *rewriter) valueAssign(s ast.Stmt) (string, bool) {
+	assign, ok := s.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return "", false
+	}
+
+	sel, field, ok := r.fieldOf(assign.Lhs[0])
+	if !ok || field == "Valid" {
+		return "", false
+	}
+
+	return types.ExprString(sel.X), true
+}
+
+func (r *rewriter) assignStmt(c *astutil.Cursor, n *ast.AssignStmt) bool {
+	if r.drop[n] {
+		// The line of the dropped statement is merged with the previous one,
+		// so that the printer doesn't keep it as an empty line.
+		file := r.fset.File(n.Pos())
+		if line := file.Line(n.Pos()); line > 1 && file.Line(n.End()) == line {
+			file.MergeLine(line - 1)
+		}
+
+		c.Delete()
+		r.changed = true
+
+		return false
+	}
+
+	for i, lhs := range n.Lhs {
+		sel, field, ok := r.fieldOf(lhs)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case len(n.Lhs) != 1 || len(n.Rhs) != 1 || n.Tok != token.ASSIGN:
+		case field == "Valid" && r.isBool(n.Rhs[0], false):
+			c.Replace(&ast.ExprStmt{X: call(r.applyExpr(sel.X), "SetNull", n.End())})
+			r.changed = true
+
+			return false
+		case field != "Valid" && r.isFollowedByValid(c, sel):
+			c.Replace(&ast.ExprStmt{X: call(r.applyExpr(sel.X), "SetValue", n.End(), r.applyExpr(n.Rhs[0]))})
+			r.changed = true
+
+			return false
+		}
+
+		r.warn(n, "cannot rewrite the assignment of "+types.ExprString(lhs))
+		r.skip[n.Lhs[i]] = true
+	}
+
+	return true
+}
+
+// isFollowedByValid returns true iff the statement x.Field = v at c is followed by x.Valid = true.
+func (r *rewriter) isFollowedByValid(c *astutil.Cursor, sel *ast.SelectorExpr) bool {
+	var list []ast.Stmt
+
+	switch parent := c.Parent().(type) {
+	case *ast.BlockStmt:
+		list = parent.List
+	case *ast.CaseClause:
+		list = parent.Body
+	case *ast.CommClause:
+		list = parent.Body
+	default:
+		return false
+	}
+
+	i := c.Index()
+	if i < 0 || i+1 >= len(list) {
+		return false
+	}
+
+	x, ok := r.validAssign(list[i+1], true)
+
+	return ok && x == types.ExprString(sel.X)
+}
+
+// selectorExpr rewrites the reads of the fields of the nullable types of database/sql.
+// The values are read with GetValue, which returns nil if the value is null: the reads which are not
+// guarded by a check of x.Valid are reported.
+func (r *rewriter) selectorExpr(c *astutil.Cursor, n *ast.SelectorExpr) bool {
+	_, field, ok := r.fieldOf(n)
+	if !ok {
+		return true
+	}
+
+	x := r.applyExpr(n.X)
+
+	switch {
+	case field == "Valid":
+		c.Replace(&ast.UnaryExpr{OpPos: n.Pos(), Op: token.NOT, X: call(x, "IsNull", n.End())})
+	default:
+		if !r.isGuarded(types.ExprString(n.X), n.Pos()) {
+			r.warn(n, fmt.Sprintf("%s is read without checking %s.Valid, its GetValue is nil if it is null",
+				types.ExprString(n), types.ExprString(n.X)))
+		}
+
+		var value ast.Expr = &ast.StarExpr{Star: n.Pos(), X: call(x, "GetValue", n.End())}
+
+		switch c.Parent().(type) {
+		case *ast.SelectorExpr, *ast.IndexExpr, *ast.SliceExpr, *ast.TypeAssertExpr, *ast.CallExpr:
+			if c.Name() == "X" || c.Name() == "Fun" {
+				value = &ast.ParenExpr{Lparen: n.Pos(), X: value, Rparen: n.End()}
+			}
+		}
+
+		c.Replace(value)
+	}
+
+	r.changed = true
+
+	return false
+}
+
+func (r *rewriter) unaryExpr(c *astutil.Cursor, n *ast.UnaryExpr) bool {
+	sel, field, ok := r.fieldOf(n.X)
+	if !ok {
+		return true
+	}
+
+	switch {
+	case n.Op == token.NOT && field == "Valid":
+		c.Replace(call(r.applyExpr(sel.X), "IsNull", n.End()))
+		r.changed = true
+
+		return false
+	case n.Op == token.AND:
+		r.warn(n, "cannot rewrite the address of "+types.ExprString(n.X))
+		r.skip[n.X] = true
+	}
+
+	return true
+}
+
+// switchStmt reports a switch on a value holding nullable types of database/sql, its cases being compared
+// with ==, and marks its tag and its case expressions not to be rewritten.
+func (r *rewriter) switchStmt(n *ast.SwitchStmt) {
+	if n.Tag == nil || !holdsSQLType(r.info.TypeOf(n.Tag)) {
+		return
+	}
+
+	r.warn(n, fmt.Sprintf("cannot rewrite the switch on %s: nullable.Of values are compared by their pointers, "+
+		"use Equal", types.ExprString(n.Tag)))
+	r.skip[n.Tag] = true
+
+	for _, s := range n.Body.List {
+		for _, e := range s.(*ast.CaseClause).List {
+			r.skip[e] = true
+		}
+	}
+}
+
+// compositeLit rewrites a composite literal of a nullable type of database/sql
+// to nullable.FromValue or nullable.Null when its validity is a constant,
+// else the literal is converted with the nullable adapter of its type.
+func (r *rewriter) compositeLit(n *ast.CompositeLit, st sqlType, elem ast.Expr) ast.Expr {
+	var value, valid ast.Expr
+
+	keyed := true
+
+	for _, elt := range n.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			keyed = false
+
+			break
+		}
+
+		kv.Value = r.applyExpr(kv.Value)
+
+		switch key, _ := kv.Key.(*ast.Ident); {
+		case key == nil:
+			keyed = false
+		case key.Name == "Valid":
+			valid = kv.Value
+		case key.Name == st.field:
+			value = kv.Value
+		}
+	}
+
+	switch {
+	case !keyed:
+	case value == nil && (valid == nil || r.isBool(valid, false)):
+		return &ast.CallExpr{
+			Fun:    &ast.IndexExpr{X: nullableSel("Null", n.Pos()), Index: elem, Lbrack: n.Lbrace, Rbrack: n.Lbrace},
+			Lparen: n.Rbrace,
+			Rparen: n.Rbrace,
+		}
+	case value != nil && valid != nil && r.isBool(valid, true):
+		// The type argument is needed if the type of the value isn't the inferred one,
+		// as for the constant 1 of a sql.NullInt64.
+		fun := ast.Expr(nullableSel("FromValue", n.Pos()))
+		if !types.Identical(r.inferredType(value), r.elemType(n, st)) {
+			fun = &ast.IndexExpr{X: fun, Index: elem, Lbrack: n.Lbrace, Rbrack: n.Lbrace}
+		}
+
+		return &ast.CallExpr{Fun: fun, Lparen: n.Lbrace, Args: []ast.Expr{value}, Rparen: n.Rbrace}
+	}
+
+	for i, elt := range n.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); !ok {
+			n.Elts[i] = r.applyExpr(elt)
+		}
+	}
+
+	return &ast.CallExpr{Fun: nullableSel(st.adapter, n.Pos()), Lparen: n.Pos(), Args: []ast.Expr{n}, Rparen: n.End()}
+}
+
+// inferredType returns the type inferred for the argument e of a generic function:
+// the default type of an untyped constant, else the type of e.
+// It returns nil if it can't be determined, for constant expressions.
+func (r *rewriter) inferredType(e ast.Expr) types.Type {
+	tv := r.info.Types[e]
+	if tv.Value == nil {
+		return tv.Type
+	}
+
+	// The recorded type of a constant is the type it is converted to, its own type is found from the syntax.
+	var t types.Type
+
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BasicLit:
+		t = types.Typ[map[token.Token]types.BasicKind{
+			token.INT:    types.UntypedInt,
+			token.FLOAT:  types.UntypedFloat,
+			token.IMAG:   types.UntypedComplex,
+			token.CHAR:   types.UntypedRune,
+			token.STRING: types.UntypedString,
+		}[e.Kind]]
+	case *ast.Ident:
+		if c, ok := r.info.Uses[e].(*types.Const); ok {
+			t = c.Type()
+		}
+	case *ast.SelectorExpr:
+		if c, ok := r.info.Uses[e.Sel].(*types.Const); ok {
+			t = c.Type()
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return types.Default(t)
+}
+
+// elemType returns the type of the value of the composite literal n of type st.
+func (r *rewriter) elemType(n *ast.CompositeLit, st sqlType) types.Type {
+	named, _ := r.info.TypeOf(n).(*types.Named)
+	if named == nil {
+		return nil
+	}
+
+	for field := range named.Underlying().(*types.Struct).Fields() {
+		if field.Name() == st.field {
+			return field.Type()
+		}
+	}
+
+	return nil
+}
+
+// typeExpr returns the nullable type of database/sql denoted by the type expression e,
+// with the expression of the type of its value.
+func (r *rewriter) typeExpr(e ast.Expr) (sqlType, ast.Expr, bool) {
+	switch e := e.(type) {
+	case *ast.SelectorExpr:
+		name, ok := r.sqlTypeName(e)
+		if !ok || name == "Null" {
+			return sqlType{}, nil, false
+		}
+
+		st := sqlTypes[name]
+		if st.elem == "time.Time" {
+			r.needTime = true
+
+			return st, &ast.SelectorExpr{X: ast.NewIdent("time"), Sel: ast.NewIdent("Time")}, true
+		}
+
+		return st, ast.NewIdent(st.elem), true
+	case *ast.IndexExpr:
+		sel, ok := e.X.(*ast.SelectorExpr)
+		if !ok {
+			return sqlType{}, nil, false
+		}
+
+		name, ok := r.sqlTypeName(sel)
+		if !ok || name != "Null" {
+			return sqlType{}, nil, false
+		}
+
+		return sqlTypes[name], r.applyExpr(e.Index), true
+	}
+
+	return sqlType{}, nil, false
+}
+
+// sqlTypeName returns the name of the nullable type of database/sql denoted by e.
+func (r *rewriter) sqlTypeName(e *ast.SelectorExpr) (string, bool) {
+	obj, ok := r.info.Uses[e.Sel].(*types.TypeName)
+	if !ok || obj.Pkg() == nil || obj.Pkg().Path() != "database/sql" {
+		return "", false
+	}
+
+	_, ok = sqlTypes[obj.Name()]
+
+	return obj.Name(), ok
+}
+
+// fieldOf returns the selector and the field name of e if e selects
+// the Valid or the value field of a nullable type of database/sql.
+func (r *rewriter) fieldOf(e ast.Expr) (*ast.SelectorExpr, string, bool) {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", false
+	}
+
+	selection := r.info.Selections[sel]
+	if selection == nil || selection.Kind() != types.FieldVal || len(selection.Index()) != 1 {
+		return nil, "", false
+	}
+
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	st, ok := sqlTypeOf(recv)
+	if !ok || (sel.Sel.Name != "Valid" && sel.Sel.Name != st.field) {
+		return nil, "", false
+	}
+
+	return sel, sel.Sel.Name, true
+}
+
+// sqlTypeOf returns the nullable type of database/sql t.
+func sqlTypeOf(t types.Type) (sqlType, bool) {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return sqlType{}, false
+	}
+
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != "database/sql" {
+		return sqlType{}, false
+	}
+
+	st, ok := sqlTypes[obj.Name()]
+
+	return st, ok
+}
+
+// holdsSQLType returns true iff the values of t hold a nullable type of database/sql, which is compared
+// by value unlike nullable.Of: t is such a type, or a struct or an array of them.
+func holdsSQLType(t types.Type) bool {
+	if _, ok := sqlTypeOf(t); ok {
+		return true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for field := range u.Fields() {
+			if holdsSQLType(field.Type()) {
+				return true
+			}
+		}
+	case *types.Array:
+		return holdsSQLType(u.Elem())
+	}
+
+	return false
+}
+
+// isBool returns true iff e is the boolean constant value.
+func (r *rewriter) isBool(e ast.Expr, value bool) bool {
+	tv, ok := r.info.Types[e]
+
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.Bool && constant.BoolVal(tv.Value) == value
+}
+
+func (r *rewriter) warn(n ast.Node, message string) {
+	r.warnings = append(r.warnings, Warning{Pos: r.fset.Position(n.Pos()), Message: message})
+}
+
+// nullableSel returns the expression nullable.name at pos.
+func nullableSel(name string, pos token.Pos) *ast.SelectorExpr {
+	return &ast.SelectorExpr{X: &ast.Ident{NamePos: pos, Name: "nullable"}, Sel: ast.NewIdent(name)}
+}
+
+// call returns the expression x.method(args...) ending at end.
+// The positions of the new nodes permit the printer to keep the comments in place.
+func call(x ast.Expr, method string, end token.Pos, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{Fun: &ast.SelectorExpr{X: x, Sel: ast.NewIdent(method)}, Lparen: end, Args: args, Rparen: end}
+}
@@ -0,0 +1,202 @@
+// Package nullgen generates concrete nullable types, with the methods of nullable.Of[T] for a given type T,
+// for the tools which handle generic types poorly. It implements the nullgen command.
+package nullgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"path"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// nullablePath is the import path of the nullable package.
+const nullablePath = "github.com/ovya/nullable"
+
+// Config is the configuration of the generation of a type.
+type Config struct {
+	// Package is the name of the package of the generated file.
+	Package string
+	// Type is the name of the generated type, such as NullEmail.
+	Type string
+	// Elem is the type of the value, such as Email, time.Time or github.com/shopspring/decimal.Decimal.
+	// The types of other packages are given by their import path, which is imported by the generated file.
+	Elem string
+	// Command is the command line recorded in the header of the generated file.
+	Command string
+}
+
+// data is the data of the template.
+type data struct {
+	Config
+	// Imports are the import paths of the generated file, besides database/sql/driver and nullable.
+	Imports []string
+	// ElemExpr is the expression of the type of the value in the generated file.
+	ElemExpr string
+}
+
+// Generate returns the formatted source of the generated file.
+func Generate(cfg Config) ([]byte, error) {
+	if !token.IsIdentifier(cfg.Package) {
+		return nil, fmt.Errorf("nullgen: invalid package name %q", cfg.Package)
+	}
+
+	if !token.IsIdentifier(cfg.Type) {
+		return nil, fmt.Errorf("nullgen: invalid type name %q", cfg.Type)
+	}
+
+	d := data{Config: cfg}
+
+	var err error
+
+	d.ElemExpr, d.Imports, err = elemExpr(cfg.Elem)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, d)
+	if err != nil {
+		return nil, fmt.Errorf("nullgen: %w", err)
+	}
+
+	// The imports are sorted and grouped, the standard library first
+	opts := &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true}
+
+	src, err := imports.Process("", buf.Bytes(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("nullgen: invalid generated code for the type %q: %w", cfg.Elem, err)
+	}
+
+	return src, nil
+}
+
+// elemExpr returns the expression of the type elem, such as []decimal.Decimal for []github.com/shopspring/decimal.Decimal,
+// and the import path of its package if it is qualified.
+func elemExpr(elem string) (string, []string, error) {
+	if elem == "" {
+		return "", nil, errors.New("nullgen: missing type of the value")
+	}
+
+	// The qualified name follows the pointer, slice and array prefixes
+	prefix := elem[:len(elem)-len(strings.TrimLeft(elem, "*[]0123456789"))]
+	name := elem[len(prefix):]
+
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return elem, nil, nil
+	}
+
+	importPath, typeName := name[:dot], name[dot+1:]
+	if importPath == "" || strings.HasSuffix(importPath, "/") || !token.IsIdentifier(typeName) {
+		return "", nil, fmt.Errorf("nullgen: invalid type %q", elem)
+	}
+
+	pkg := path.Base(importPath)
+	if !token.IsIdentifier(pkg) {
+		return "", nil, fmt.Errorf("nullgen: the package name of %q is not its last path element", importPath)
+	}
+
+	if importPath == nullablePath {
+		// Imported anyway
+		return prefix + pkg + "." + typeName, nil, nil
+	}
+
+	return prefix + pkg + "." + typeName, []string{importPath}, nil
+}
+
+var tmpl = template.Must(template.New("nullgen").Parse(`// Code generated by "{{.Command}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"github.com/ovya/nullable"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// {{.Type}} is a nullable {{.ElemExpr}} with the methods of nullable.Of[{{.ElemExpr}}].
+// As with nullable.Of, the setters of a nil *{{.Type}} do nothing,
+// while Scan and UnmarshalJSON require a non-nil receiver.
+type {{.Type}} struct {
+	of nullable.Of[{{.ElemExpr}}]
+}
+
+// {{.Type}}From returns the {{.Type}} holding the value of n.
+func {{.Type}}From(n nullable.Of[{{.ElemExpr}}]) {{.Type}} {
+	return {{.Type}}{of: n}
+}
+
+// Of returns the nullable.Of[{{.ElemExpr}}] holding the value of n.
+func (n {{.Type}}) Of() nullable.Of[{{.ElemExpr}}] {
+	return n.of
+}
+
+// IsNull returns true iff the value is null.
+func (n *{{.Type}}) IsNull() bool {
+	return n == nil || n.of.IsNull()
+}
+
+// GetValue returns a pointer to the value, nil if null.
+func (n *{{.Type}}) GetValue() *{{.ElemExpr}} {
+	if n == nil {
+		return nil
+	}
+
+	return n.of.GetValue()
+}
+
+// SetValue sets the value.
+func (n *{{.Type}}) SetValue(v {{.ElemExpr}}) {
+	if n == nil {
+		return
+	}
+
+	n.of.SetValue(v)
+}
+
+// SetValueP sets the value pointed to by ref, or null if ref is nil.
+func (n *{{.Type}}) SetValueP(ref *{{.ElemExpr}}) {
+	if n == nil {
+		return
+	}
+
+	n.of.SetValueP(ref)
+}
+
+// SetNull sets to null.
+func (n *{{.Type}}) SetNull() {
+	if n == nil {
+		return
+	}
+
+	n.of.SetNull()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n {{.Type}}) MarshalJSON() ([]byte, error) {
+	return n.of.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *{{.Type}}) UnmarshalJSON(data []byte) error {
+	return n.of.UnmarshalJSON(data)
+}
+
+// Value implements the driver.Valuer interface.
+func (n {{.Type}}) Value() (driver.Value, error) {
+	return n.of.Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *{{.Type}}) Scan(v any) error {
+	return n.of.Scan(v)
+}
+`))
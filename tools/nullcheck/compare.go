@@ -0,0 +1,54 @@
+package nullcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkComparison reports the comparison e if it compares nullable.Of values, or values holding them.
+// The comparison of two nullable.Of values is fixed by a call to Equal.
+func checkComparison(pass *analysis.Pass, e *ast.BinaryExpr) {
+	if e.Op != token.EQL && e.Op != token.NEQ {
+		return
+	}
+
+	x, y := pass.TypesInfo.TypeOf(e.X), pass.TypesInfo.TypeOf(e.Y)
+	if x == nil || y == nil {
+		return
+	}
+
+	switch {
+	case isOf(x) && isOf(y):
+		fix := operand(e.X) + ".Equal(" + types.ExprString(e.Y) + ")"
+		if e.Op == token.NEQ {
+			fix = "!" + fix
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     e.Pos(),
+			End:     e.End(),
+			Message: "comparison of nullable.Of values with " + e.Op.String() + " compares their pointers, use Equal",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Use Equal",
+				TextEdits: []analysis.TextEdit{{Pos: e.Pos(), End: e.End(), NewText: []byte(fix)}},
+			}},
+		})
+	case containsOf(x) || containsOf(y):
+		pass.ReportRangef(e, "comparison with %s compares the pointers of the nullable.Of values instead of their values",
+			e.Op)
+	}
+}
+
+// operand returns the expression e as the operand of a method call, parenthesized if needed.
+func operand(e ast.Expr) string {
+	switch e.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.ParenExpr,
+		*ast.CompositeLit:
+		return types.ExprString(e)
+	default:
+		return "(" + types.ExprString(e) + ")"
+	}
+}
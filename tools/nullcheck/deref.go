@@ -0,0 +1,392 @@
+package nullcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+)
+
+// facts is the set of the paths known not to be null.
+type facts map[path]bool
+
+// intersect removes from f the paths which are not in g and returns true iff f is modified.
+func (f facts) intersect(g facts) bool {
+	modified := false
+
+	for p := range f {
+		if !g[p] {
+			delete(f, p)
+
+			modified = true
+		}
+	}
+
+	return modified
+}
+
+// with returns a copy of f with the paths ps added.
+func (f facts) with(ps []path) facts {
+	if len(ps) == 0 {
+		return f
+	}
+
+	g := maps.Clone(f)
+	for _, p := range ps {
+		g[p] = true
+	}
+
+	return g
+}
+
+// funcs are the control-flow graphs of the functions of a package, with its function literals
+// called where they are defined.
+type funcs struct {
+	cfgs   *ctrlflow.CFGs
+	called map[*ast.FuncLit]bool
+}
+
+// calledFuncLits returns the function literals called where they are defined, as func() { ... }(),
+// except by a defer or a go statement, which runs them later.
+func calledFuncLits(in *inspector.Inspector) map[*ast.FuncLit]bool {
+	called := map[*ast.FuncLit]bool{}
+	later := map[*ast.CallExpr]bool{}
+
+	in.Preorder([]ast.Node{(*ast.DeferStmt)(nil), (*ast.GoStmt)(nil), (*ast.CallExpr)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.DeferStmt:
+			later[n.Call] = true
+		case *ast.GoStmt:
+			later[n.Call] = true
+		case *ast.CallExpr:
+			if lit, ok := ast.Unparen(n.Fun).(*ast.FuncLit); ok && !later[n] {
+				called[lit] = true
+			}
+		}
+	})
+
+	return called
+}
+
+// derefChecker checks the dereferences of GetValue() in the body of a function.
+type derefChecker struct {
+	pass  *analysis.Pass
+	funcs *funcs
+	// conds are the conditions of the branches of the function.
+	conds map[ast.Expr]bool
+	// ranged are the keys and values of the range loops, assigned at each iteration.
+	ranged map[ast.Expr]bool
+}
+
+// checkDerefs reports the dereferences of GetValue() in body which are not guarded by an IsNull check.
+// The paths known not to be null are computed by a forward data flow analysis of the control-flow graph g:
+// a path is known not to be null at a point iff it is on every path from the entry of the function,
+// where the paths of entry are known not to be null.
+func checkDerefs(pass *analysis.Pass, fs *funcs, g *cfg.CFG, body *ast.BlockStmt, entry facts) {
+	if g == nil {
+		return
+	}
+
+	c := &derefChecker{pass: pass, funcs: fs, conds: map[ast.Expr]bool{}, ranged: map[ast.Expr]bool{}}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			c.conds[n.Cond] = true
+		case *ast.ForStmt:
+			if n.Cond != nil {
+				c.conds[n.Cond] = true
+			}
+		case *ast.SwitchStmt:
+			if n.Tag == nil {
+				for _, clause := range n.Body.List {
+					for _, e := range clause.(*ast.CaseClause).List {
+						c.conds[e] = true
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Key != nil {
+				c.ranged[n.Key] = true
+			}
+
+			if n.Value != nil {
+				c.ranged[n.Value] = true
+			}
+		}
+
+		return true
+	})
+
+	// in are the facts at the entry of the blocks, nil for the blocks not reached yet.
+	in := make([]facts, len(g.Blocks))
+	in[0] = maps.Clone(entry)
+
+	for work := []*cfg.Block{g.Blocks[0]}; len(work) > 0; {
+		b := work[len(work)-1]
+		work = work[:len(work)-1]
+
+		out := maps.Clone(in[b.Index])
+		for _, n := range b.Nodes {
+			c.update(out, n)
+		}
+
+		for i, succ := range b.Succs {
+			edge := out
+			if cond := c.condition(b); cond != nil {
+				edge = out.with(c.guarded(cond, i == 0))
+			}
+
+			if in[succ.Index] == nil {
+				in[succ.Index] = maps.Clone(edge)
+				work = append(work, succ)
+			} else if in[succ.Index].intersect(edge) {
+				work = append(work, succ)
+			}
+		}
+	}
+
+	for _, b := range g.Blocks {
+		f := in[b.Index]
+		if f == nil {
+			continue
+		}
+
+		for _, n := range b.Nodes {
+			c.check(n, f)
+			c.update(f, n)
+		}
+	}
+}
+
+// condition returns the condition of the branch ending the block b, nil if it doesn't end with a branch.
+func (c *derefChecker) condition(b *cfg.Block) ast.Expr {
+	if len(b.Succs) != 2 || len(b.Nodes) == 0 {
+		return nil
+	}
+
+	cond, ok := b.Nodes[len(b.Nodes)-1].(ast.Expr)
+	if !ok || !c.conds[cond] {
+		return nil
+	}
+
+	return cond
+}
+
+// guarded returns the paths known not to be null when the condition cond evaluates to branch.
+func (c *derefChecker) guarded(cond ast.Expr, branch bool) []path {
+	switch e := ast.Unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		if e.Op == token.NOT {
+			return c.guarded(e.X, !branch)
+		}
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.LAND:
+			if branch {
+				return append(c.guarded(e.X, true), c.guarded(e.Y, true)...)
+			}
+		case token.LOR:
+			if !branch {
+				return append(c.guarded(e.X, false), c.guarded(e.Y, false)...)
+			}
+		case token.EQL, token.NEQ:
+			if branch == (e.Op == token.NEQ) {
+				return append(c.notNil(e.X, e.Y), c.notNil(e.Y, e.X)...)
+			}
+		}
+	case *ast.CallExpr:
+		if recv, ok := methodCall(c.pass.TypesInfo, e, "IsNull"); ok && !branch {
+			if p, ok := pathOf(c.pass.TypesInfo, recv); ok {
+				return []path{p}
+			}
+		}
+	}
+
+	return nil
+}
+
+// notNil returns the path of the receiver of x if x is a call of GetValue() and y is nil.
+func (c *derefChecker) notNil(x, y ast.Expr) []path {
+	if !c.pass.TypesInfo.Types[y].IsNil() {
+		return nil
+	}
+
+	recv, ok := methodCall(c.pass.TypesInfo, x, "GetValue")
+	if !ok {
+		return nil
+	}
+
+	p, ok := pathOf(c.pass.TypesInfo, recv)
+	if !ok {
+		return nil
+	}
+
+	return []path{p}
+}
+
+// check reports the unguarded dereferences of GetValue() in the node n, f being the facts before n.
+func (c *derefChecker) check(n ast.Node, f facts) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Checked on its own, as it may be called anywhere, or with the facts of its call below
+			return false
+		case *ast.CallExpr:
+			if lit, ok := ast.Unparen(n.Fun).(*ast.FuncLit); ok && c.funcs.called[lit] {
+				checkDerefs(c.pass, c.funcs, c.funcs.cfgs.FuncLit(lit), lit.Body, f)
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				c.check(n.X, f)
+				c.check(n.Y, f.with(c.guarded(n.X, n.Op == token.LAND)))
+
+				return false
+			}
+		case *ast.StarExpr:
+			c.checkDeref(n, n.X, f)
+		case *ast.SelectorExpr:
+			// x.GetValue().Field implicitly dereferences the pointer
+			if sel := c.pass.TypesInfo.Selections[n]; sel != nil && sel.Kind() == types.FieldVal {
+				c.checkDeref(n, n.X, f)
+			}
+		}
+
+		return true
+	})
+}
+
+// checkDeref reports the dereference n of the pointer e if e is a call of GetValue() whose receiver is not in f.
+func (c *derefChecker) checkDeref(n ast.Node, e ast.Expr, f facts) {
+	recv, ok := methodCall(c.pass.TypesInfo, e, "GetValue")
+	if !ok {
+		return
+	}
+
+	if p, ok := pathOf(c.pass.TypesInfo, recv); ok && f[p] {
+		return
+	}
+
+	c.pass.ReportRangef(n, "dereference of %s.GetValue() is not guarded by an IsNull check", types.ExprString(recv))
+}
+
+// update updates the facts f after the node n.
+func (c *derefChecker) update(f facts, n ast.Node) {
+	if e, ok := n.(ast.Expr); ok && c.ranged[e] {
+		c.kill(f, e)
+
+		return
+	}
+
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			for _, rhs := range n.Rhs {
+				c.update(f, rhs)
+			}
+
+			for i, lhs := range n.Lhs {
+				c.kill(f, lhs)
+
+				if len(n.Lhs) == len(n.Rhs) && c.notNull(f, n.Rhs[i]) {
+					c.gen(f, lhs)
+				}
+			}
+
+			return false
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if len(n.Names) == len(n.Values) && c.notNull(f, n.Values[i]) {
+					c.gen(f, name)
+				}
+			}
+		case *ast.IncDecStmt:
+			c.kill(f, n.X)
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				// The variable may be modified through its address
+				c.kill(f, n.X)
+			}
+		case *ast.CallExpr:
+			c.updateCall(f, n)
+		}
+
+		return true
+	})
+}
+
+// updateCall updates the facts f after the method call.
+func (c *derefChecker) updateCall(f facts, call *ast.CallExpr) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	selection := c.pass.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return
+	}
+
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	if isOf(recv) {
+		switch sel.Sel.Name {
+		case "SetValue":
+			c.gen(f, sel.X)
+		case "SetValueP", "SetNull", "Scan", "UnmarshalJSON":
+			c.kill(f, sel.X)
+		}
+
+		return
+	}
+
+	// A method with a pointer receiver may modify the receiver
+	sig := selection.Obj().Type().(*types.Signature)
+	if _, ok := sig.Recv().Type().(*types.Pointer); ok {
+		c.kill(f, sel.X)
+	}
+}
+
+// notNull returns true iff the expression e, evaluated with the facts f, is known not to be null.
+func (c *derefChecker) notNull(f facts, e ast.Expr) bool {
+	if isFromValue(c.pass.TypesInfo, e) {
+		return true
+	}
+
+	p, ok := pathOf(c.pass.TypesInfo, e)
+
+	return ok && f[p] && isOf(c.pass.TypesInfo.TypeOf(e))
+}
+
+// gen adds the path of e to f.
+func (c *derefChecker) gen(f facts, e ast.Expr) {
+	if p, ok := pathOf(c.pass.TypesInfo, e); ok {
+		f[p] = true
+	}
+}
+
+// kill removes from f the path of e and its parts.
+func (c *derefChecker) kill(f facts, e ast.Expr) {
+	p, ok := pathOf(c.pass.TypesInfo, e)
+	if !ok {
+		return
+	}
+
+	for q := range f {
+		if within(q, p) {
+			delete(f, q)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Package nullcheck defines an analyzer reporting the misuses of nullable.Of[T]:
+
+  - the dereferences of GetValue() which are not guarded by an IsNull check, and panic if the value is null;
+  - the comparisons of nullable.Of values with == or !=, which compare their pointers instead of their values;
+  - the writes through GetValue() to a value shared by copies: a copy of a nullable.Of value shares the pointer
+    to the value, so that writing through GetValue() modifies every copy, unlike SetValue.
+
+A dereference is guarded if it can only be reached when the value is known not to be null,
+because of a condition on IsNull() or GetValue() != nil, or after a call to SetValue:
+
+	if n.IsNull() {
+		return
+	}
+
+	fmt.Println(*n.GetValue())
+
+The guards hold in the function literals called where they are defined, as func() { ... }(),
+but not in the other function literals, which may be called after the value is set to null.
+
+The analyzer is run by the nullcheck command, on its own or by go vet:
+
+	go install github.com/ovya/nullable/tools/cmd/nullcheck@latest
+	nullcheck ./...
+	go vet -vettool=$(which nullcheck) ./...
+*/
+package nullcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// nullablePath is the import path of the nullable package.
+const nullablePath = "github.com/ovya/nullable"
+
+// Analyzer reports the misuses of nullable.Of[T].
+var Analyzer = &analysis.Analyzer{
+	Name: "nullcheck",
+	Doc: "report the misuses of nullable.Of values\n\n" +
+		"The analyzer reports the dereferences of GetValue() not guarded by an IsNull check, " +
+		"the comparisons of nullable.Of values with == or !=, which compare pointers, " +
+		"and the writes through GetValue() to a value shared by copies.",
+	URL:      "https://pkg.go.dev/github.com/ovya/nullable/tools/nullcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	fs := &funcs{cfgs: pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs), called: calledFuncLits(in)}
+
+	in.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil), (*ast.BinaryExpr)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				checkDerefs(pass, fs, fs.cfgs.FuncDecl(n), n.Body, facts{})
+			}
+		case *ast.FuncLit:
+			// The function literals called where they are defined are checked at their call
+			if !fs.called[n] {
+				checkDerefs(pass, fs, fs.cfgs.FuncLit(n), n.Body, facts{})
+			}
+		case *ast.BinaryExpr:
+			checkComparison(pass, n)
+		}
+	})
+
+	checkSharedWrites(pass, in)
+
+	return nil, nil
+}
+
+// isOf returns true iff t is an instance of nullable.Of.
+func isOf(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Origin().Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == nullablePath && obj.Name() == "Of"
+}
+
+// containsOf returns true iff t is nullable.Of, or a struct or an array holding nullable.Of values
+// without indirection, so that copying a value of type t copies their pointers.
+func containsOf(t types.Type) bool {
+	if isOf(t) {
+		return true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for field := range u.Fields() {
+			if containsOf(field.Type()) {
+				return true
+			}
+		}
+	case *types.Array:
+		return containsOf(u.Elem())
+	}
+
+	return false
+}
+
+// methodCall returns the receiver of e if it is a call of the method name of nullable.Of.
+func methodCall(info *types.Info, e ast.Expr, name string) (ast.Expr, bool) {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return nil, false
+	}
+
+	selection := info.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil, false
+	}
+
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	if !isOf(recv) {
+		return nil, false
+	}
+
+	return sel.X, true
+}
+
+// isFromValue returns true iff e is a call of nullable.FromValue.
+func isFromValue(info *types.Info, e ast.Expr) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	fun := ast.Unparen(call.Fun)
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+
+	var id *ast.Ident
+
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return false
+	}
+
+	obj, ok := info.Uses[id].(*types.Func)
+
+	return ok && obj.Pkg() != nil && obj.Pkg().Path() == nullablePath && obj.Name() == "FromValue"
+}
+
+// path identifies a variable or a part of a variable, such as u.Name or items[0],
+// by the object of the variable and the expression.
+type path struct {
+	root types.Object
+	expr string
+}
+
+// pathOf returns the path of e if it is a variable or a part of a variable.
+// Parts whose address is computed by a call, such as f().Name, have no path.
+func pathOf(info *types.Info, e ast.Expr) (path, bool) {
+	e = ast.Unparen(e)
+
+	root := rootOf(info, e)
+	if root == nil {
+		return path{}, false
+	}
+
+	return path{root: root, expr: types.ExprString(e)}, true
+}
+
+// rootOf returns the variable of which e is a part, nil if it has none.
+func rootOf(info *types.Info, e ast.Expr) types.Object {
+	for {
+		switch x := e.(type) {
+		case *ast.ParenExpr:
+			e = x.X
+		case *ast.StarExpr:
+			e = x.X
+		case *ast.IndexExpr:
+			e = x.X
+		case *ast.SelectorExpr:
+			if v, ok := info.Uses[x.Sel].(*types.Var); ok && info.Selections[x] == nil {
+				// Qualified identifier of a package variable
+				return v
+			}
+
+			e = x.X
+		case *ast.Ident:
+			if v, ok := info.ObjectOf(x).(*types.Var); ok {
+				return v
+			}
+
+			return nil
+		default:
+			return nil
+		}
+	}
+}
+
+// within returns true iff p is q or a part of q.
+func within(p, q path) bool {
+	if p.root != q.root || !strings.HasPrefix(p.expr, q.expr) {
+		return false
+	}
+
+	rest := p.expr[len(q.expr):]
+
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
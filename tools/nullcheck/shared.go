@@ -0,0 +1,224 @@
+package nullcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// copied is a copy of a value holding nullable.Of values, which shares their pointers with the original value.
+type copied struct {
+	copy path
+	// original is the path of the original value, if it is a variable.
+	original    path
+	hasOriginal bool
+	// name describes the original value.
+	name string
+	pos  token.Pos
+}
+
+// sharing finds the copies of the values holding nullable.Of values, and the writes through GetValue().
+type sharing struct {
+	pass   *analysis.Pass
+	copies []copied
+	// pointers are the receivers of GetValue() by variable holding its result.
+	pointers map[types.Object]ast.Expr
+}
+
+// checkSharedWrites reports the writes through GetValue() to a value shared by copies,
+// such as *b.GetValue() = v after b := a, which modifies a too.
+// The copies are the assignments and the declarations of variables, the values of the range loops,
+// and the parameters and receivers, which are copies of the values of the caller.
+func checkSharedWrites(pass *analysis.Pass, in *inspector.Inspector) {
+	s := &sharing{pass: pass, pointers: map[types.Object]ast.Expr{}}
+
+	in.Preorder([]ast.Node{
+		(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil), (*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil), (*ast.RangeStmt)(nil),
+	}, s.collect)
+
+	in.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.IncDecStmt)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				for _, lhs := range n.Lhs {
+					s.checkWrite(lhs)
+				}
+			}
+		case *ast.IncDecStmt:
+			s.checkWrite(n.X)
+		}
+	})
+}
+
+// collect records the copies and the pointers returned by GetValue() in the node n.
+func (s *sharing) collect(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.FuncDecl:
+		s.params(n.Recv, n.Pos())
+		s.params(n.Type.Params, n.Pos())
+	case *ast.FuncLit:
+		s.params(n.Type.Params, n.Pos())
+	case *ast.AssignStmt:
+		if len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				s.assign(lhs, n.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		if len(n.Names) == len(n.Values) {
+			for i, name := range n.Names {
+				s.assign(name, n.Values[i])
+			}
+		}
+	case *ast.RangeStmt:
+		s.rangeValue(n)
+	}
+}
+
+// params records the parameters of a function declared at pos which hold nullable.Of values.
+func (s *sharing) params(fields *ast.FieldList, pos token.Pos) {
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			if p, ok := s.copyOf(name); ok {
+				s.copies = append(s.copies, copied{copy: p, name: "the value of the caller", pos: pos})
+			}
+		}
+	}
+}
+
+// assign records the assignment of rhs to lhs if it is a copy or a pointer returned by GetValue().
+func (s *sharing) assign(lhs, rhs ast.Expr) {
+	if recv, ok := methodCall(s.pass.TypesInfo, rhs, "GetValue"); ok {
+		if id, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+			if obj := s.pass.TypesInfo.ObjectOf(id); obj != nil {
+				s.pointers[obj] = recv
+			}
+		}
+
+		return
+	}
+
+	p, ok := s.copyOf(lhs)
+	if !ok {
+		return
+	}
+
+	original, ok := pathOf(s.pass.TypesInfo, rhs)
+	if !ok {
+		// A value returned by a call or a literal is not shared
+		return
+	}
+
+	s.copies = append(s.copies, copied{
+		copy:        p,
+		original:    original,
+		hasOriginal: true,
+		name:        types.ExprString(ast.Unparen(rhs)),
+		pos:         lhs.Pos(),
+	})
+}
+
+// rangeValue records the value of the range loop n if it is a copy of an element holding nullable.Of values.
+func (s *sharing) rangeValue(n *ast.RangeStmt) {
+	if n.Value == nil {
+		return
+	}
+
+	p, ok := s.copyOf(n.Value)
+	if !ok {
+		return
+	}
+
+	s.copies = append(s.copies, copied{
+		copy: p,
+		name: "an element of " + types.ExprString(n.X),
+		pos:  n.Value.Pos(),
+	})
+}
+
+// copyOf returns the path of e if its type holds nullable.Of values.
+func (s *sharing) copyOf(e ast.Expr) (path, bool) {
+	t := s.pass.TypesInfo.TypeOf(e)
+	if t == nil || !containsOf(t) {
+		return path{}, false
+	}
+
+	return pathOf(s.pass.TypesInfo, e)
+}
+
+// checkWrite reports the write to lhs if it is a write through GetValue() to a value shared by a copy.
+func (s *sharing) checkWrite(lhs ast.Expr) {
+	recv, ok := s.written(lhs)
+	if !ok {
+		return
+	}
+
+	p, ok := pathOf(s.pass.TypesInfo, recv)
+	if !ok {
+		return
+	}
+
+	for _, c := range s.copies {
+		if c.pos >= lhs.Pos() {
+			continue
+		}
+
+		if within(p, c.copy) || c.hasOriginal && within(p, c.original) {
+			s.pass.ReportRangef(lhs, "%s is a copy sharing the values of %s: "+
+				"writing through %s.GetValue() modifies both, use SetValue", c.copy.expr, c.name, types.ExprString(recv))
+
+			return
+		}
+	}
+}
+
+// written returns the receiver of GetValue() if lhs is a part of the value it points to.
+func (s *sharing) written(lhs ast.Expr) (ast.Expr, bool) {
+	for {
+		switch e := ast.Unparen(lhs).(type) {
+		case *ast.StarExpr:
+			return s.pointee(e.X)
+		case *ast.SelectorExpr:
+			sel := s.pass.TypesInfo.Selections[e]
+			if sel == nil || sel.Kind() != types.FieldVal {
+				return nil, false
+			}
+
+			if _, ok := s.pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Pointer); ok {
+				return s.pointee(e.X)
+			}
+
+			lhs = e.X
+		case *ast.IndexExpr:
+			if _, ok := s.pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Array); !ok {
+				return nil, false
+			}
+
+			lhs = e.X
+		default:
+			return nil, false
+		}
+	}
+}
+
+// pointee returns the receiver of GetValue() if the pointer e is returned by GetValue().
+func (s *sharing) pointee(e ast.Expr) (ast.Expr, bool) {
+	if recv, ok := methodCall(s.pass.TypesInfo, e, "GetValue"); ok {
+		return recv, true
+	}
+
+	if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+		recv, ok := s.pointers[s.pass.TypesInfo.ObjectOf(id)]
+
+		return recv, ok
+	}
+
+	return nil, false
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ovya/nullable/tools/internal/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update is set to update the golden files instead of checking them.
+var update = os.Getenv("UPDATE_GOLDEN") != ""
+
+// TestMigrateGolden rewrites the packages of testdata/migrate. The rewriting of each file is compared
+// to the file .golden, and the warnings to warnings.golden. Run with UPDATE_GOLDEN=1 to update the golden files.
+func TestMigrateGolden(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "migrate", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, dirs)
+
+	for _, dir := range dirs {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			results, err := migrate.Run(dir, ".")
+			require.NoError(t, err)
+			require.NotEmpty(t, results)
+
+			var warnings strings.Builder
+
+			for _, result := range results {
+				assert.True(t, result.Changed(), "%s should be rewritten", result.Filename)
+
+				for _, w := range result.Warnings {
+					w.Pos.Filename = filepath.Base(w.Pos.Filename)
+					warnings.WriteString(w.String() + "\n")
+				}
+
+				checkGolden(t, result.Filename+".golden", result.After)
+			}
+
+			checkGolden(t, filepath.Join(dir, "warnings.golden"), []byte(warnings.String()))
+		})
+	}
+}
+
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+
+	if update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "missing golden file, run with UPDATE_GOLDEN=1")
+	assert.Equal(t, string(want), string(got), "differs from %s", golden)
+}
+
+func TestMigrateDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	want := `--- a/file.go
++++ b/file.go
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+`
+	assert.Equal(t, want, string(migrate.Diff("file.go", []byte(before), []byte(after))))
+	assert.Equal(t, "--- a/file.go\n+++ b/file.go\n", string(migrate.Diff("file.go", []byte(before), []byte(before))))
+}
@@ -0,0 +1,21 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ovya/nullable/tools/nullcheck"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestNullcheck runs the analyzer on testdata/nullcheck. The diagnostics are checked against the want comments
+// of the files, and the suggested fixes against the files .golden.
+func TestNullcheck(t *testing.T) {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+
+	// The testdata is a module of its own requiring the nullable module, so that the tools module does not
+	analysistest.RunWithSuggestedFixes(t, filepath.Join(dir, "testdata", "nullcheck"), nullcheck.Analyzer, ".")
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ovya/nullable/tools/internal/nullgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNullgenGolden compares the generated files to the files of testdata/nullgen.
+// Run with UPDATE_GOLDEN=1 to update the golden files.
+func TestNullgenGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		cfg    nullgen.Config
+	}{
+		{"nullemail.go.golden", nullgen.Config{Package: "users", Type: "NullEmail", Elem: "Email"}},
+		{"nulltime.go.golden", nullgen.Config{Package: "models", Type: "NullTime", Elem: "time.Time"}},
+		{"nulldecimal.go.golden", nullgen.Config{
+			Package: "models", Type: "NullDecimal", Elem: "github.com/shopspring/decimal.Decimal",
+		}},
+		{"nullids.go.golden", nullgen.Config{Package: "models", Type: "NullIDs", Elem: "[]github.com/google/uuid.UUID"}},
+		{"nulldate.go.golden", nullgen.Config{Package: "models", Type: "NullDate", Elem: "github.com/ovya/nullable.Date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			tt.cfg.Command = "nullgen -type " + tt.cfg.Type + " -elem " + tt.cfg.Elem
+
+			got, err := nullgen.Generate(tt.cfg)
+			require.NoError(t, err)
+
+			checkGolden(t, filepath.Join("testdata", "nullgen", tt.golden), got)
+		})
+	}
+}
+
+// TestNullgenUpToDate checks that the generated files of the tests of the nullable module match the output of nullgen.
+func TestNullgenUpToDate(t *testing.T) {
+	tests := []struct {
+		file string
+		cfg  nullgen.Config
+	}{
+		{"nullamount_nullgen_test.go", nullgen.Config{
+			Type: "NullAmount", Elem: "amount",
+			Command: "nullgen -type NullAmount -elem amount -output ../tests/nullamount_nullgen_test.go",
+		}},
+		{"nulluuid_nullgen_test.go", nullgen.Config{
+			Type: "NullUUID", Elem: "github.com/google/uuid.UUID",
+			Command: "nullgen -type NullUUID -elem github.com/google/uuid.UUID -output ../tests/nulluuid_nullgen_test.go",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			tt.cfg.Package = "tests"
+
+			want, err := nullgen.Generate(tt.cfg)
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join("..", "..", "tests", tt.file))
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), "run go generate in tests")
+		})
+	}
+}
+
+func TestNullgenErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  nullgen.Config
+	}{
+		{"missing package", nullgen.Config{Type: "NullEmail", Elem: "Email"}},
+		{"invalid type name", nullgen.Config{Package: "users", Type: "Null-Email", Elem: "Email"}},
+		{"missing elem", nullgen.Config{Package: "users", Type: "NullEmail"}},
+		{"invalid elem", nullgen.Config{Package: "users", Type: "NullEmail", Elem: "time."}},
+		{"unnamed package", nullgen.Config{Package: "users", Type: "NullEmail", Elem: "gopkg.in/yaml.v3.Node"}},
+		{"invalid code", nullgen.Config{Package: "users", Type: "NullEmail", Elem: "map[string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := nullgen.Generate(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
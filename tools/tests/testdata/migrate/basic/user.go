@@ -0,0 +1,88 @@
+package basic
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// User is a row of the users table.
+type User struct {
+	ID       int64
+	Name     sql.NullString
+	Age      sql.NullInt64
+	Score    sql.NullFloat64
+	Active   sql.NullBool
+	LastSeen sql.NullTime
+	Nickname sql.Null[string]
+}
+
+func NewUser(name string, age int64) User {
+	return User{
+		Name:     sql.NullString{String: name, Valid: true},
+		Age:      sql.NullInt64{Int64: age, Valid: true},
+		Score:    sql.NullFloat64{Float64: 1.5, Valid: true},
+		Active:   sql.NullBool{},
+		Nickname: sql.Null[string]{V: strings.ToLower(name), Valid: true},
+	}
+}
+
+func (u *User) Describe() string {
+	if !u.Name.Valid {
+		return "anonymous"
+	}
+
+	out := u.Name.String
+
+	if u.Age.Valid && u.Age.Int64 > 0 {
+		out += fmt.Sprintf(" (%d)", u.Age.Int64)
+	}
+
+	if u.LastSeen.Valid {
+		out += " seen in " + u.LastSeen.Time.Format("2006")
+	}
+
+	return out + " " + u.Nickname.V
+}
+
+// Rename sets the name, or clears it if empty.
+func (u *User) Rename(name string) {
+	if name == "" {
+		u.Name.Valid = false
+
+		return
+	}
+
+	u.Name.String = name
+	u.Name.Valid = true
+}
+
+func Score(u User) float64 {
+	// The zero value is returned for a null score
+	return u.Score.Float64 * 2
+}
+
+func Age(age int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: age, Valid: age > 0}
+}
+
+func Defaults() User {
+	return User{
+		Name:  sql.NullString{String: "nobody", Valid: true},
+		Age:   sql.NullInt64{Int64: 18, Valid: true},
+		Score: sql.NullFloat64{Float64: 0, Valid: true},
+	}
+}
+
+const adultAge int64 = 18
+
+const unknown = "unknown"
+
+func Typed() User {
+	return User{
+		Name:   sql.NullString{String: unknown, Valid: true},
+		Age:    sql.NullInt64{Int64: adultAge, Valid: true},
+		Active: sql.NullBool{Bool: true, Valid: true},
+		Score:  sql.NullFloat64{Float64: 2 * 3, Valid: true},
+	}
+}
@@ -0,0 +1,76 @@
+package warnings
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Event struct {
+	Title   sql.NullString
+	Count   sql.NullInt32
+	At      sql.NullTime
+	Ratio   *sql.NullFloat64
+	Created time.Time
+}
+
+func Load(db *sql.DB, id int) (Event, error) {
+	var e Event
+
+	// Scanning by address keeps working with nullable.Of, but can't be rewritten
+	err := db.QueryRow("SELECT title FROM events WHERE id = $1", id).Scan(&e.Title.String)
+
+	return e, err
+}
+
+func (e *Event) Touch(ok bool) {
+	e.Count.Int32++
+	e.Title.Valid = ok
+
+	switch {
+	case ok:
+		e.At.Time = time.Now()
+		e.At.Valid = true
+	default:
+		e.At = sql.NullTime{}
+	}
+
+	if e.Ratio != nil && e.Ratio.Valid {
+		e.Ratio.Float64 /= 2
+	}
+}
+
+func Values(events []Event) []int32 {
+	var out []int32
+
+	for _, e := range events {
+		if e.Count.Valid {
+			out = append(out, e.Count.Int32)
+		}
+	}
+
+	return out
+}
+
+// The comparisons of nullable.Of values compare their pointers, they can't be rewritten
+func Same(a, b Event) bool {
+	return a.Title == b.Title || a != b
+}
+
+func Counts(events []Event) map[sql.NullString]int {
+	counts := map[sql.NullString]int{}
+
+	for _, e := range events {
+		counts[e.Title]++
+	}
+
+	return counts
+}
+
+func Label(e Event) string {
+	switch e.Title {
+	case sql.NullString{}:
+		return "untitled"
+	default:
+		return "titled"
+	}
+}
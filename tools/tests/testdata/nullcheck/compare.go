@@ -0,0 +1,23 @@
+package nullcheck
+
+import "github.com/ovya/nullable"
+
+func compare(a, b nullable.Of[int], p *nullable.Of[int]) bool {
+	if a == b { // want `comparison of nullable.Of values with == compares their pointers, use Equal`
+		return true
+	}
+
+	if *p != a { // want `comparison of nullable.Of values with != compares their pointers, use Equal`
+		return false
+	}
+
+	return a == nullable.Null[int]() // want `comparison of nullable.Of values with ==`
+}
+
+func compareStructs(u, v User) bool {
+	return u == v // want `comparison with == compares the pointers of the nullable.Of values instead of their values`
+}
+
+func comparePointers(a, b *nullable.Of[int]) bool {
+	return a == b
+}
@@ -0,0 +1,193 @@
+package nullcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ovya/nullable"
+)
+
+type User struct {
+	Name nullable.Of[string]
+	Age  nullable.Of[int]
+}
+
+func unguarded(n nullable.Of[int]) int {
+	return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded by an IsNull check`
+}
+
+func guardedIf(n nullable.Of[int]) int {
+	if !n.IsNull() {
+		return *n.GetValue()
+	}
+
+	return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded`
+}
+
+func earlyReturn(u *User) string {
+	if u.Name.IsNull() {
+		return ""
+	}
+
+	return *u.Name.GetValue() + fmt.Sprint(*u.Age.GetValue()) // want `dereference of u.Age.GetValue\(\) is not guarded`
+}
+
+func exit(u User) {
+	if u.Name.IsNull() {
+		os.Exit(1)
+	}
+
+	fmt.Println(*u.Name.GetValue())
+}
+
+func notNil(n nullable.Of[string]) {
+	if n.GetValue() != nil {
+		fmt.Println(*n.GetValue())
+	}
+
+	if nil == n.GetValue() {
+		return
+	}
+
+	fmt.Println(*n.GetValue())
+}
+
+func shortCircuit(a, b nullable.Of[int]) bool {
+	if !a.IsNull() && *a.GetValue() > 0 {
+		return true
+	}
+
+	return a.IsNull() || b.IsNull() || *a.GetValue() > *b.GetValue()
+}
+
+func conjunction(a, b nullable.Of[int]) int {
+	if a.IsNull() || b.IsNull() {
+		return 0
+	}
+
+	return *a.GetValue() + *b.GetValue()
+}
+
+func onlyOneBranch(n nullable.Of[int], ok bool) int {
+	if ok && !n.IsNull() {
+		fmt.Println(*n.GetValue())
+	}
+
+	if ok || !n.IsNull() {
+		return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded`
+	}
+
+	return 0
+}
+
+func setValue(n nullable.Of[int]) int {
+	n.SetValue(1)
+	fmt.Println(*n.GetValue())
+
+	n.SetNull()
+
+	return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded`
+}
+
+func fromValue() int {
+	n := nullable.FromValue(1)
+	m := n
+
+	return *n.GetValue() + *m.GetValue()
+}
+
+func reassigned(n, m nullable.Of[int]) int {
+	if n.IsNull() {
+		return 0
+	}
+
+	n = m
+
+	return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded`
+}
+
+func addressTaken(u User, data []byte) string {
+	if u.Name.IsNull() {
+		return ""
+	}
+
+	_ = json.Unmarshal(data, &u)
+
+	return *u.Name.GetValue() // want `dereference of u.Name.GetValue\(\) is not guarded`
+}
+
+func loop(users []User) {
+	for _, u := range users {
+		if u.Name.IsNull() {
+			continue
+		}
+
+		fmt.Println(*u.Name.GetValue())
+	}
+
+	for i := range users {
+		if !users[i].Age.IsNull() {
+			users[i].Age.SetValue(*users[i].Age.GetValue() + 1)
+		}
+	}
+}
+
+func switchCase(n nullable.Of[int]) int {
+	switch {
+	case n.IsNull():
+		return 0
+	case *n.GetValue() > 0:
+		return *n.GetValue()
+	}
+
+	return -*n.GetValue()
+}
+
+func closure(n nullable.Of[int]) func() int {
+	if n.IsNull() {
+		return nil
+	}
+
+	return func() int {
+		return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded`
+	}
+}
+
+func immediateClosure(n nullable.Of[int]) int {
+	if n.IsNull() {
+		return 0
+	}
+
+	return func() int {
+		return *n.GetValue()
+	}()
+}
+
+func immediateClosureUnguarded(n nullable.Of[int]) int {
+	return func() int {
+		return *n.GetValue() // want `dereference of n.GetValue\(\) is not guarded`
+	}()
+}
+
+func deferredClosure(n nullable.Of[int]) {
+	if n.IsNull() {
+		return
+	}
+
+	defer func() {
+		fmt.Println(*n.GetValue()) // want `dereference of n.GetValue\(\) is not guarded`
+	}()
+
+	n.SetNull()
+}
+
+type point struct{ X, Y int }
+
+func fieldOfValue(p nullable.Of[point]) int {
+	if p.IsNull() {
+		return p.GetValue().Y // want `dereference of p.GetValue\(\) is not guarded`
+	}
+
+	return p.GetValue().X
+}
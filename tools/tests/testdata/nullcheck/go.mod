@@ -0,0 +1,9 @@
+module nullcheck
+
+go 1.24
+
+require github.com/ovya/nullable v0.0.0
+
+require github.com/google/uuid v1.6.0 // indirect
+
+replace github.com/ovya/nullable => ../../../..
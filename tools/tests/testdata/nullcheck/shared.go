@@ -0,0 +1,72 @@
+package nullcheck
+
+import "github.com/ovya/nullable"
+
+func copyThenWrite(a nullable.Of[int]) {
+	if a.IsNull() {
+		return
+	}
+
+	*a.GetValue() = 1 // want `a is a copy sharing the values of the value of the caller: writing through a.GetValue\(\) modifies both, use SetValue`
+}
+
+func copyOfVariable() (nullable.Of[int], nullable.Of[int]) {
+	a := nullable.FromValue(1)
+	b := a
+
+	*b.GetValue() = 2 // want `b is a copy sharing the values of a`
+	*a.GetValue()++   // want `b is a copy sharing the values of a`
+
+	c := nullable.FromValue(1)
+	*c.GetValue() = 3
+
+	return a, b
+}
+
+func copyOfStruct() User {
+	u := User{Name: nullable.FromValue("a")}
+	v := u
+
+	p := v.Name.GetValue()
+	*p = "b" // want `v is a copy sharing the values of u: writing through v.Name.GetValue\(\) modifies both`
+
+	v.Name.SetValue("c")
+
+	return u
+}
+
+func rangeCopy(users []User) {
+	for _, u := range users {
+		if u.Age.IsNull() {
+			continue
+		}
+
+		*u.Age.GetValue() += 1 // want `u is a copy sharing the values of an element of users`
+	}
+
+	for i := range users {
+		users[i].Age.SetValue(1)
+	}
+}
+
+type box struct{ N int }
+
+func (u User) rename() {
+	if u.Name.IsNull() {
+		return
+	}
+
+	*u.Name.GetValue() = "x" // want `u is a copy sharing the values of the value of the caller`
+}
+
+func (u *User) renamePointer() {
+	u.Name.SetValue("x")
+}
+
+func fieldWrite(b nullable.Of[box]) {
+	if b.IsNull() {
+		return
+	}
+
+	b.GetValue().N = 1 // want `b is a copy sharing the values of the value of the caller`
+}